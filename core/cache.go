@@ -0,0 +1,114 @@
+package elemental
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// The default number of entries held by the in-memory cache store used when no other store has been set.
+const DefaultCacheCapacity = 10000
+
+// A store which holds the results of cached queries. Implementations must be safe for concurrent use.
+// A ttl of zero means that the entry never expires, although it can still be evicted or deleted.
+type CacheStore interface {
+	Get(key string) (any, bool)
+	Set(key string, value any, ttl time.Duration)
+	Delete(key string)
+}
+
+var (
+	cacheStore   CacheStore = NewMemoryCache(DefaultCacheCapacity)
+	cacheStoreMu sync.RWMutex
+)
+
+// Sets the store used by all models to cache query results. Passing nil disables caching altogether.
+func SetCacheStore(store CacheStore) {
+	cacheStoreMu.Lock()
+	defer cacheStoreMu.Unlock()
+	cacheStore = store
+}
+
+// Returns the store currently used to cache query results, or nil if caching is disabled.
+func currentCacheStore() CacheStore {
+	cacheStoreMu.RLock()
+	defer cacheStoreMu.RUnlock()
+	return cacheStore
+}
+
+// An in-memory least recently used cache store with a fixed capacity.
+type MemoryCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // Front is the most recently used entry
+	mu       sync.Mutex
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// Creates a new in-memory cache store which evicts the least recently used entry once it holds more than the given number of entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *MemoryCache) Set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &memoryCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Returns the number of entries currently held by the cache, including expired ones which have not been accessed since.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*memoryCacheEntry).key)
+}
@@ -6,4 +6,13 @@ var (
 	ErrURIRequired               = errors.New("URI is required")
 	ErrInvalidConnectionArgument = errors.New("invalid connection argument")
 	ErrMustPairSortArguments     = errors.New("sort arguments must be in pairs")
+	ErrInvalidCursor             = errors.New("invalid or tampered pagination cursor")
+	ErrNotNumeric                = errors.New("value must be a number")
+	ErrDivisionByZero            = errors.New("cannot divide by zero")
+	ErrInvalidChunkSize          = errors.New("chunk size must be greater than zero")
+	ErrUnsupportedFormat         = errors.New("unsupported data format")
+	ErrUnknownScope              = errors.New("unknown scope")
+	ErrTenantRequired            = errors.New("a tenant is required within the context of this query")
+	ErrTenantMismatch            = errors.New("document belongs to another tenant")
+	ErrDeleteRestricted          = errors.New("document is still referenced")
 )
@@ -0,0 +1,63 @@
+package elemental
+
+import "slices"
+
+const (
+	GeoJSONPoint      = "Point"
+	GeoJSONPolygon    = "Polygon"
+	GeoJSONLineString = "LineString"
+)
+
+// The radius of the earth in meters as used by MongoDB for spherical geometry.
+const earthRadiusInMeters = 6378100
+
+// A GeoJSON point. Coordinates are in the order of longitude and latitude.
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// A GeoJSON polygon made up of one or more linear rings. The first ring is the exterior ring and any others are holes within it.
+type GeoPolygon struct {
+	Type        string        `json:"type" bson:"type"`
+	Coordinates [][][]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// A GeoJSON line string made up of two or more positions.
+type GeoLineString struct {
+	Type        string      `json:"type" bson:"type"`
+	Coordinates [][]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// A circle on the surface of the earth. This is not a GeoJSON type and can only be used within queries such as GeoWithin.
+type GeoCircle struct {
+	Center GeoPoint // The center of the circle
+	Radius float64  // The radius of the circle in meters
+}
+
+// Creates a new GeoJSON point with the given longitude and latitude.
+func NewGeoPoint(longitude, latitude float64) GeoPoint {
+	return GeoPoint{Type: GeoJSONPoint, Coordinates: []float64{longitude, latitude}}
+}
+
+// Creates a new GeoJSON polygon with the given rings where each position is a pair of longitude and latitude.
+// Rings which are not closed are closed automatically by repeating their first position.
+func NewGeoPolygon(rings ...[][]float64) GeoPolygon {
+	rings = slices.Clone(rings)
+	for i, ring := range rings {
+		if len(ring) > 0 && (ring[0][0] != ring[len(ring)-1][0] || ring[0][1] != ring[len(ring)-1][1]) {
+			rings[i] = append(slices.Clone(ring), ring[0])
+		}
+	}
+	return GeoPolygon{Type: GeoJSONPolygon, Coordinates: rings}
+}
+
+// Creates a new GeoJSON line string with the given positions where each position is a pair of longitude and latitude.
+func NewGeoLineString(positions ...[]float64) GeoLineString {
+	return GeoLineString{Type: GeoJSONLineString, Coordinates: positions}
+}
+
+// Creates a new circle with the given center and radius in meters.
+func NewGeoCircle(center GeoPoint, radius float64) GeoCircle {
+	return GeoCircle{Center: center, Radius: radius}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/elcengine/elemental/utils"
 	"github.com/spf13/cast"
@@ -13,6 +14,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type ModelInterface[T any] interface {
@@ -30,12 +34,24 @@ type Model[T any] struct {
 	whereField          string
 	failWith            *error
 	orConditionActive   bool
+	notConditionActive  bool
 	upsert              bool
 	returnNew           bool
+	updateOperators     map[string]primitive.M
+	updateMode          updateMode
+	queryOptions        queryOptions
 	middleware          *middleware[T]
+	scopes              *scopeRegistry[T]
+	excludedScopes      []string
+	tenant              any // The tenant resolved from the context of the executing query in field mode
+	transactional       bool
 	temporaryConnection *string
 	temporaryDatabase   *string
 	temporaryCollection *string
+	readPreference      *readpref.ReadPref
+	readConcern         *readconcern.ReadConcern
+	writeConcern        *writeconcern.WriteConcern
+	cacheTTL            *time.Duration
 	schedule            *string
 	onScheduleExecError *func(any)
 	softDeleteEnabled   bool
@@ -64,6 +80,7 @@ func NewModel[T any](name string, schema Schema) Model[T] {
 		Name:        name,
 		Schema:      schema,
 		middleware:  &middleware,
+		scopes:      newScopeRegistry[T](),
 		triggerExit: make(chan bool, 1),
 	}
 	model.preprocess()
@@ -87,7 +104,8 @@ func NewModel[T any](name string, schema Schema) Model[T] {
 // This method validates the document against the model schema and panics if any errors are found.
 func (m Model[T]) Create(doc T) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
-		documentToInsert := enforceSchema(m.Schema, &doc, nil)
+		defer m.invalidateCache()
+		documentToInsert := m.stampTenant(enforceSchema(m.Schema, &doc, nil))
 		m.middleware.pre.save.run(&documentToInsert)
 		lo.Must(m.Collection().InsertOne(ctx, documentToInsert))
 		m.middleware.post.save.run(&documentToInsert)
@@ -106,9 +124,10 @@ func (m Model[T]) CreateMany(docs []T) Model[T] {
 // This method validates the document against the model schema and panics if any errors are found.
 func (m Model[T]) InsertMany(docs []T) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		var documentsToInsert []any
 		for _, doc := range docs {
-			documentsToInsert = append(documentsToInsert, enforceSchema(m.Schema, &doc, nil))
+			documentsToInsert = append(documentsToInsert, m.stampTenant(enforceSchema(m.Schema, &doc, nil)))
 		}
 		lo.Must(m.Collection().InsertMany(ctx, documentsToInsert))
 		return utils.CastBSONSlice[T](documentsToInsert)
@@ -122,16 +141,13 @@ func (m Model[T]) InsertMany(docs []T) Model[T] {
 func (m Model[T]) Find(query ...primitive.M) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
 		var results []T
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		m.checkConditionsAndPanicForErr(cursor.All(ctx, &results))
 		m.checkConditionsAndPanic(results)
 		m.middleware.post.find.run(&results)
 		return results
 	}
 	q := utils.MergedQueryOrDefault(query)
-	if m.softDeleteEnabled {
-		q[m.deletedAtFieldName] = primitive.M{"$exists": false}
-	}
 	m.pipeline = append(m.pipeline, bson.D{{Key: "$match", Value: q}})
 	return m
 }
@@ -141,16 +157,13 @@ func (m Model[T]) Find(query ...primitive.M) Model[T] {
 // If multiple queries are provided, they are merged into a single from left to right.
 func (m Model[T]) FindOne(query ...primitive.M) Model[T] {
 	q := utils.MergedQueryOrDefault(query)
-	if m.softDeleteEnabled {
-		q[m.deletedAtFieldName] = primitive.M{"$exists": false}
-	}
 	m.pipeline = append(m.pipeline,
 		bson.D{{Key: "$match", Value: q}},
 		bson.D{{Key: "$limit", Value: 1}},
 	)
 	m.executor = func(m Model[T], ctx context.Context) any {
 		var results []T
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		m.checkConditionsAndPanicForErr(cursor.All(ctx, &results))
 		m.checkConditionsAndPanic(results)
 		if len(results) == 0 {
@@ -165,9 +178,6 @@ func (m Model[T]) FindOne(query ...primitive.M) Model[T] {
 // The id can be a string or an ObjectID.
 func (m Model[T]) FindByID(id any) Model[T] {
 	q := primitive.M{"_id": utils.EnsureObjectID(id)}
-	if m.softDeleteEnabled {
-		q[m.deletedAtFieldName] = primitive.M{"$exists": false}
-	}
 	return m.FindOne(q)
 }
 
@@ -176,13 +186,10 @@ func (m Model[T]) FindByID(id any) Model[T] {
 // If multiple queries are provided, they are merged into a single from left to right.
 func (m Model[T]) CountDocuments(query ...primitive.M) Model[T] {
 	q := utils.MergedQueryOrDefault(query)
-	if m.softDeleteEnabled {
-		q[m.deletedAtFieldName] = primitive.M{"$exists": false}
-	}
 	m.pipeline = append(m.pipeline, bson.D{{Key: "$match", Value: q}}, bson.D{{Key: "$count", Value: "count"}})
 	m.executor = func(m Model[T], ctx context.Context) any {
 		var results []map[string]any
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		m.checkConditionsAndPanicForErr(cursor.All(ctx, &results))
 		if len(results) == 0 {
 			return 0
@@ -195,15 +202,13 @@ func (m Model[T]) CountDocuments(query ...primitive.M) Model[T] {
 // Distinct returns a list of distinct values for the given field.
 // It optionally accepts one or more queries to filter the results before getting the distinct values.
 // If multiple queries are provided, they are merged into a single from left to right.
+// The values are returned as strings, use DistinctT to decode them into their actual type instead.
 func (m Model[T]) Distinct(field string, query ...primitive.M) Model[T] {
 	q := utils.MergedQueryOrDefault(query)
-	if m.softDeleteEnabled {
-		q[m.deletedAtFieldName] = primitive.M{"$exists": false}
-	}
 	m.pipeline = append(m.pipeline, bson.D{{Key: "$match", Value: q}}, bson.D{{Key: "$group", Value: primitive.M{"_id": "$" + field}}})
 	m.executor = func(m Model[T], ctx context.Context) any {
 		var results []map[string]any
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		m.checkConditionsAndPanicForErr(cursor.All(ctx, &results))
 		var distinct = make([]string, 0, len(results))
 		for _, result := range results {
@@ -273,12 +278,24 @@ func (m Model[T]) Clone() Model[T] {
 		whereField:          m.whereField,
 		failWith:            m.failWith,
 		orConditionActive:   m.orConditionActive,
+		notConditionActive:  m.notConditionActive,
 		upsert:              m.upsert,
 		returnNew:           m.returnNew,
+		updateOperators:     m.updateOperators,
+		updateMode:          m.updateMode,
+		queryOptions:        m.queryOptions,
 		middleware:          m.middleware,
+		scopes:              m.scopes,
+		excludedScopes:      m.excludedScopes,
+		tenant:              m.tenant,
+		transactional:       m.transactional,
 		temporaryConnection: m.temporaryConnection,
 		temporaryDatabase:   m.temporaryDatabase,
 		temporaryCollection: m.temporaryCollection,
+		readPreference:      m.readPreference,
+		readConcern:         m.readConcern,
+		writeConcern:        m.writeConcern,
+		cacheTTL:            m.cacheTTL,
 		schedule:            m.schedule,
 		softDeleteEnabled:   m.softDeleteEnabled,
 		deletedAtFieldName:  m.deletedAtFieldName,
@@ -7,6 +7,9 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // Creates the collection used by this model. This method will only create the collection if it does not exist.
@@ -65,3 +68,25 @@ func (m Model[T]) SetCollection(collection string) Model[T] {
 	m.temporaryCollection = &collection
 	return m
 }
+
+// Sets the read preference for the next operation only, overriding the read preference of the schema or the client.
+//
+// Usage:
+//
+//	UserModel.Find().ReadFrom(readpref.SecondaryPreferred()).ExecTT()
+func (m Model[T]) ReadFrom(readPreference *readpref.ReadPref) Model[T] {
+	m.readPreference = readPreference
+	return m
+}
+
+// Sets the read concern for the next operation only, overriding the read concern of the schema or the client.
+func (m Model[T]) WithReadConcern(readConcern *readconcern.ReadConcern) Model[T] {
+	m.readConcern = readConcern
+	return m
+}
+
+// Sets the write concern for the next operation only, overriding the write concern of the schema or the client.
+func (m Model[T]) WithWriteConcern(writeConcern *writeconcern.WriteConcern) Model[T] {
+	m.writeConcern = writeConcern
+	return m
+}
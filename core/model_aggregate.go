@@ -0,0 +1,245 @@
+package elemental
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BucketOptions struct {
+	Default any         // The identifier of the bucket which holds documents that fall outside of the given boundaries
+	Output  primitive.M // The fields to include in each output document besides the _id
+}
+
+type BucketAutoOptions struct {
+	Output      primitive.M // The fields to include in each output document besides the _id
+	Granularity string      // A preferred number series to ensure that boundary edges end on such numbers. E.g. "R5", "1-2-5", "POWERSOF2"
+}
+
+type MergeOptions struct {
+	Database       string // The database of the output collection. Defaults to the database of the model
+	On             any    // The field or fields that act as a unique identifier for a document. Defaults to _id
+	Let            any    // Variables to use within the whenMatched pipeline
+	WhenMatched    any    // The behavior if a result document and an existing document have the same value for the on field(s)
+	WhenNotMatched string // The behavior if a result document does not match an existing document. E.g. "insert", "discard", "fail"
+}
+
+// Extends the query with a group stage which groups documents by the given expression.
+// It optionally accepts one or more maps of accumulators which are merged into a single from left to right.
+func (m Model[T]) Group(id any, accumulators ...primitive.M) Model[T] {
+	group := utils.MergedQueryOrDefault(accumulators)
+	group["_id"] = id
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$group", Value: group}})
+	return m
+}
+
+// Extends the query with an unwind stage which deconstructs the array in the given field into a document per element.
+// Optionally accepts a flag to preserve documents where the field is null, missing or an empty array.
+func (m Model[T]) Unwind(field string, preserveNullAndEmptyArrays ...bool) Model[T] {
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$unwind", Value: primitive.M{
+		"path":                       fieldPath(field),
+		"preserveNullAndEmptyArrays": lo.FirstOrEmpty(preserveNullAndEmptyArrays),
+	}}})
+	return m
+}
+
+// Extends the query with an addFields stage which adds new fields or overwrites existing fields in each document.
+func (m Model[T]) AddFields(fields primitive.M) Model[T] {
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$addFields", Value: fields}})
+	return m
+}
+
+// Extends the query with a bucket stage which categorizes documents into groups based on the given expression and boundaries.
+func (m Model[T]) Bucket(groupBy any, boundaries []any, opts ...BucketOptions) Model[T] {
+	bucket := primitive.M{
+		"groupBy":    groupBy,
+		"boundaries": boundaries,
+	}
+	if len(opts) > 0 {
+		if opts[0].Default != nil {
+			bucket["default"] = opts[0].Default
+		}
+		if opts[0].Output != nil {
+			bucket["output"] = opts[0].Output
+		}
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$bucket", Value: bucket}})
+	return m
+}
+
+// Extends the query with a bucketAuto stage which categorizes documents into the given number of evenly distributed groups.
+func (m Model[T]) BucketAuto(groupBy any, buckets int, opts ...BucketAutoOptions) Model[T] {
+	bucket := primitive.M{
+		"groupBy": groupBy,
+		"buckets": buckets,
+	}
+	if len(opts) > 0 {
+		if opts[0].Output != nil {
+			bucket["output"] = opts[0].Output
+		}
+		if opts[0].Granularity != "" {
+			bucket["granularity"] = opts[0].Granularity
+		}
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$bucketAuto", Value: bucket}})
+	return m
+}
+
+// Extends the query with a facet stage which processes multiple sub pipelines on the same set of input documents.
+// Each key of the given map becomes a field in the output document holding the results of its pipeline.
+func (m Model[T]) Facet(facets map[string]mongo.Pipeline) Model[T] {
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$facet", Value: facets}})
+	return m
+}
+
+// Extends the query with a replaceRoot stage which replaces each document with the given embedded document or expression.
+// A plain field name is treated as a field path.
+func (m Model[T]) ReplaceRoot(newRoot any) Model[T] {
+	if field, ok := newRoot.(string); ok {
+		newRoot = fieldPath(field)
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$replaceRoot", Value: primitive.M{"newRoot": newRoot}}})
+	return m
+}
+
+// Extends the query with a unionWith stage which combines the results with the documents of the given collection.
+// Optionally accepts a pipeline to apply on the documents of the given collection before they are combined.
+func (m Model[T]) UnionWith(collection string, pipeline ...bson.D) Model[T] {
+	union := primitive.M{"coll": collection}
+	if len(pipeline) > 0 {
+		union["pipeline"] = pipeline
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$unionWith", Value: union}})
+	return m
+}
+
+// Extends the query with a setWindowFields stage which performs operations on a specified span of documents.
+// The partitionBy expression can be nil if all documents should belong to the same partition.
+func (m Model[T]) SetWindowFields(partitionBy any, sortBy bson.D, output primitive.M) Model[T] {
+	window := primitive.M{"output": output}
+	if partitionBy != nil {
+		window["partitionBy"] = partitionBy
+	}
+	if len(sortBy) > 0 {
+		window["sortBy"] = sortBy
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$setWindowFields", Value: window}})
+	return m
+}
+
+// Extends the query with a sample stage which randomly selects the given number of documents.
+func (m Model[T]) Sample(size int64) Model[T] {
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$sample", Value: primitive.M{"size": size}}})
+	return m
+}
+
+// Extends the query with an out stage which writes the results to the given collection, replacing it if it exists.
+// Optionally accepts a database name if the collection belongs to a different database.
+// The query will not return any documents when this stage is used.
+func (m Model[T]) Out(collection string, database ...string) Model[T] {
+	var out any = collection
+	if len(database) > 0 {
+		out = primitive.M{"db": database[0], "coll": collection}
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$out", Value: out}})
+	return m
+}
+
+// Extends the query with a merge stage which merges the results into the given collection.
+// The query will not return any documents when this stage is used.
+func (m Model[T]) Merge(collection string, opts ...MergeOptions) Model[T] {
+	var into any = collection
+	merge := primitive.M{}
+	if len(opts) > 0 {
+		if opts[0].Database != "" {
+			into = primitive.M{"db": opts[0].Database, "coll": collection}
+		}
+		if opts[0].On != nil {
+			merge["on"] = opts[0].On
+		}
+		if opts[0].Let != nil {
+			merge["let"] = opts[0].Let
+		}
+		if opts[0].WhenMatched != nil {
+			merge["whenMatched"] = opts[0].WhenMatched
+		}
+		if opts[0].WhenNotMatched != "" {
+			merge["whenNotMatched"] = opts[0].WhenNotMatched
+		}
+	}
+	merge["into"] = into
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$merge", Value: merge}})
+	return m
+}
+
+// Aggregate executes the query pipeline and decodes the resulting documents into a slice of the given result type.
+// It is useful when the shape of the output differs from the model type such as after a group or bucket stage.
+//
+// Usage:
+//
+//	elemental.Aggregate[Stats](UserModel.Group("$occupation", primitive.M{"count": primitive.M{"$sum": 1}}))
+func Aggregate[R any, T any](m Model[T], ctx ...context.Context) []R {
+	results := AggregateInto[[]R](m, ctx...)
+	if results == nil {
+		return make([]R, 0)
+	}
+	return results
+}
+
+// AggregateInto executes the query pipeline through Exec and decodes the resulting documents into a value of the given result type.
+// The result type is usually a slice, although it can be a single struct or map in which case only the first document is decoded.
+//
+// Usage:
+//
+//	stats := elemental.AggregateInto[[]Stats](UserModel.Stage(bson.D{{Key: "$sortByCount", Value: "$occupation"}}))
+//	oldest := elemental.AggregateInto[User](UserModel.Sort("age", -1))
+func AggregateInto[R any, T any](m Model[T], ctx ...context.Context) R {
+	var result R
+	m.setResult(result)
+	m.executor = func(m Model[T], ctx context.Context) any {
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
+		defer cursor.Close(ctx)
+		if reflect.ValueOf(m.result).Elem().Kind() == reflect.Slice {
+			m.checkConditionsAndPanicForErr(cursor.All(ctx, m.result))
+		} else if cursor.Next(ctx) {
+			m.checkConditionsAndPanicForErr(cursor.Decode(m.result))
+		} else {
+			m.checkConditionsAndPanicForErr(cursor.Err())
+			if m.failWith != nil {
+				panic(*m.failWith)
+			}
+		}
+		m.checkConditionsAndPanic(m.result)
+		return m.result
+	}
+	m.ExecInto(&result, ctx...)
+	return result
+}
+
+// Appends the given stages to the query pipeline as they are.
+// It is useful for stages which cannot be expressed with the other methods of the builder.
+//
+// Usage:
+//
+//	UserModel.Where("age").GreaterThan(30).Stage(bson.D{{Key: "$sortByCount", Value: "$occupation"}})
+func (m Model[T]) Stage(stages ...bson.D) Model[T] {
+	m.pipeline = append(m.pipeline, stages...)
+	return m
+}
+
+// Stages which must be the first stage of a pipeline, hence the filters of global scopes have to be placed after them.
+var leadingStages = []string{"$geoNear", "$search", "$searchMeta", "$vectorSearch", "$collStats", "$indexStats"}
+
+// Prefixes the given field name with a $ sign if it is not already an expression.
+func fieldPath(field string) string {
+	if strings.HasPrefix(field, "$") {
+		return field
+	}
+	return "$" + field
+}
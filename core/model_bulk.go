@@ -0,0 +1,282 @@
+package elemental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BulkResult struct {
+	InsertedCount int64         `json:"insertedCount"` // The number of documents inserted
+	MatchedCount  int64         `json:"matchedCount"`  // The number of documents matched by update and replace operations
+	ModifiedCount int64         `json:"modifiedCount"` // The number of documents modified by update and replace operations
+	DeletedCount  int64         `json:"deletedCount"`  // The number of documents deleted
+	UpsertedCount int64         `json:"upsertedCount"` // The number of documents upserted by update and replace operations
+	InsertedIDs   map[int]any   `json:"insertedIds"`   // The _id of each inserted document keyed by the index of its operation
+	UpsertedIDs   map[int]any   `json:"upsertedIds"`   // The _id of each upserted document keyed by the index of its operation
+	Errors        map[int]error `json:"-"`             // The error of each failed operation keyed by the index of the operation
+}
+
+// A builder which collects a mix of write operations to be executed in a single round trip.
+// Operations are indexed in the order in which they are added.
+type Bulk[T any] struct {
+	model      Model[T]
+	operations []bulkOperation[T]
+	unordered  bool
+}
+
+type bulkOperation[T any] struct {
+	build func(m Model[T]) mongo.WriteModel // Builds the write model, panicking if the operation is invalid
+	after func(m Model[T], err error)       // Runs after the operation has been executed, with the error it was rejected with if any
+}
+
+// Returns a new bulk write builder for this model.
+// The operations are executed in order by default, stopping at the first error.
+//
+// Usage:
+//
+//	result := UserModel.Bulk().
+//		InsertOne(User{Name: "Geralt"}).
+//		UpdateOne(&primitive.M{"name": "Yennefer"}, primitive.M{"age": 100}).
+//		DeleteMany(primitive.M{"age": primitive.M{"$gt": 200}}).
+//		Exec()
+func (m Model[T]) Bulk() Bulk[T] {
+	return Bulk[T]{model: m}
+}
+
+// Executes the operations in order, stopping at the first error. This is the default mode.
+func (b Bulk[T]) Ordered() Bulk[T] {
+	b.unordered = false
+	return b
+}
+
+// Executes the operations in any order, continuing with the remaining operations if one of them fails.
+func (b Bulk[T]) Unordered() Bulk[T] {
+	b.unordered = true
+	return b
+}
+
+// Adds an insert operation for the given document.
+// The document is validated against the model schema and the save middleware is run for it.
+func (b Bulk[T]) InsertOne(doc T) Bulk[T] {
+	var documentToInsert bson.M
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			documentToInsert = m.stampTenant(enforceSchema(m.Schema, &doc, nil))
+			m.middleware.pre.save.run(&documentToInsert)
+			return mongo.NewInsertOneModel().SetDocument(documentToInsert)
+		},
+		after: func(m Model[T], err error) {
+			if err == nil {
+				m.middleware.post.save.run(&documentToInsert)
+			}
+		},
+	})
+}
+
+// Adds an update operation which updates the first document matching the given query.
+// The update one middleware is run for it. Since a bulk write does not report the outcome of each operation,
+// the post hooks receive a nil result along with the error the operation was rejected with, if any.
+func (b Bulk[T]) UpdateOne(query *primitive.M, doc any) Bulk[T] {
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			m.middleware.pre.updateOne.run(&doc)
+			return mongo.NewUpdateOneModel().SetFilter(m.withGlobalScopeFilters(lo.FromPtr(query))).SetUpdate(primitive.M{"$set": m.parseDocument(doc)})
+		},
+		after: func(m Model[T], err error) {
+			m.middleware.post.updateOne.run((*mongo.UpdateResult)(nil), err)
+		},
+	})
+}
+
+// Adds an update operation which updates all documents matching the given query.
+// The update many middleware is run for it, with the post hooks receiving a nil result as with UpdateOne.
+func (b Bulk[T]) UpdateMany(query *primitive.M, doc any) Bulk[T] {
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			m.middleware.pre.updateMany.run(&doc)
+			return mongo.NewUpdateManyModel().SetFilter(m.withGlobalScopeFilters(lo.FromPtr(query))).SetUpdate(primitive.M{"$set": m.parseDocument(doc)})
+		},
+		after: func(m Model[T], err error) {
+			m.middleware.post.updateMany.run((*mongo.UpdateResult)(nil), err)
+		},
+	})
+}
+
+// Adds a replace operation which replaces the first document matching the given query.
+// The replacement document is validated against the model schema.
+func (b Bulk[T]) ReplaceOne(query *primitive.M, doc T) Bulk[T] {
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			replacement := m.stampTenant(enforceSchema(m.Schema, &doc, nil, false))
+			if utils.IsEmpty(replacement["_id"]) {
+				delete(replacement, "_id") // The _id of the replaced document is immutable
+			}
+			return mongo.NewReplaceOneModel().SetFilter(m.withGlobalScopeFilters(lo.FromPtr(query))).SetReplacement(replacement)
+		},
+	})
+}
+
+// Adds an upsert operation for the given document, matching an existing document by the given key fields which default to _id.
+// The document is validated against the model schema. A matched document is updated with all fields of the given document
+// except for the _id and the creation timestamp, which are only set when the document is created.
+func (b Bulk[T]) Upsert(doc T, keyFields ...string) Bulk[T] {
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			document := m.stampTenant(enforceSchema(m.Schema, &doc, nil))
+			filter := primitive.M{}
+			for _, key := range lo.CoalesceSliceOrEmpty(keyFields, []string{"_id"}) {
+				filter[key] = document[key]
+			}
+			insertOnly := m.insertOnlyFields()
+			return mongo.NewUpdateOneModel().SetUpsert(true).SetFilter(m.withGlobalScopeFilters(filter)).SetUpdate(primitive.M{
+				"$set":         lo.OmitByKeys(document, insertOnly),
+				"$setOnInsert": lo.PickByKeys(document, insertOnly),
+			})
+		},
+	})
+}
+
+// Adds a delete operation which deletes the first document matching the given query(s).
+// If the model has soft delete enabled, the document is updated with a deleted_at field instead of being deleted.
+// The same middleware as DeleteOne is run for it, that is the update one middleware if the document is soft deleted
+// and the delete one middleware otherwise. The post hooks receive a nil result as with UpdateOne.
+func (b Bulk[T]) DeleteOne(query ...primitive.M) Bulk[T] {
+	q := utils.MergedQueryOrDefault(query)
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			if m.softDeleteEnabled {
+				var update any = m.softDeletePayload()
+				m.middleware.pre.updateOne.run(&update)
+				return mongo.NewUpdateOneModel().SetFilter(m.withGlobalScopeFilters(q)).SetUpdate(primitive.M{"$set": m.parseDocument(update)})
+			}
+			m.middleware.pre.deleteOne.run(&q)
+			return mongo.NewDeleteOneModel().SetFilter(m.withGlobalScopeFilters(q))
+		},
+		after: func(m Model[T], err error) {
+			if m.softDeleteEnabled {
+				m.middleware.post.updateOne.run((*mongo.UpdateResult)(nil), err)
+				return
+			}
+			m.middleware.post.deleteOne.run((*mongo.DeleteResult)(nil), err)
+		},
+	})
+}
+
+// Adds a delete operation which deletes all documents matching the given query(s).
+// If the model has soft delete enabled, the documents are updated with a deleted_at field instead of being deleted.
+// The same middleware as DeleteMany is run for it, that is the update many middleware if the documents are soft deleted
+// and the delete many middleware otherwise. The post hooks receive a nil result as with UpdateOne.
+func (b Bulk[T]) DeleteMany(query ...primitive.M) Bulk[T] {
+	q := utils.MergedQueryOrDefault(query)
+	return b.add(bulkOperation[T]{
+		build: func(m Model[T]) mongo.WriteModel {
+			if m.softDeleteEnabled {
+				var update any = m.softDeletePayload()
+				m.middleware.pre.updateMany.run(&update)
+				return mongo.NewUpdateManyModel().SetFilter(m.withGlobalScopeFilters(q)).SetUpdate(primitive.M{"$set": m.parseDocument(update)})
+			}
+			m.middleware.pre.deleteMany.run(&q)
+			return mongo.NewDeleteManyModel().SetFilter(m.withGlobalScopeFilters(q))
+		},
+		after: func(m Model[T], err error) {
+			if m.softDeleteEnabled {
+				m.middleware.post.updateMany.run((*mongo.UpdateResult)(nil), err)
+				return
+			}
+			m.middleware.post.deleteMany.run((*mongo.DeleteResult)(nil), err)
+		},
+	})
+}
+
+// Returns the number of operations added so far.
+func (b Bulk[T]) Len() int {
+	return len(b.operations)
+}
+
+// Executes all operations in a single bulk write.
+// Operations which fail validation or are rejected by the server are reported in the Errors map of the result against their index
+// instead of panicking. In ordered mode, no operation after the first failed one is executed.
+func (b Bulk[T]) Exec(ctx ...context.Context) BulkResult {
+	result := BulkResult{
+		InsertedIDs: make(map[int]any),
+		UpsertedIDs: make(map[int]any),
+		Errors:      make(map[int]error),
+	}
+	b.model = b.model.withTenant(utils.CtxOrDefault(ctx))
+	var writeModels []mongo.WriteModel
+	var indexes []int // The index of the operation of each write model
+	for i, operation := range b.operations {
+		failed := false
+		lo.TryCatchWithErrorValue(func() error {
+			writeModels = append(writeModels, operation.build(b.model))
+			indexes = append(indexes, i)
+			return nil
+		}, func(err any) {
+			failed = true
+			result.Errors[i] = toError(err)
+		})
+		if failed && !b.unordered {
+			break
+		}
+	}
+	if len(writeModels) == 0 {
+		return result
+	}
+	defer b.model.invalidateCache()
+	res, err := b.model.Collection().BulkWrite(utils.CtxOrDefault(ctx), writeModels,
+		parseUpdateOptions(b.model, []*options.BulkWriteOptions{options.BulkWrite().SetOrdered(!b.unordered)})...)
+	var bulkWriteException mongo.BulkWriteException
+	if err != nil && !errors.As(err, &bulkWriteException) {
+		panic(err)
+	}
+	executed := len(writeModels)
+	for _, writeError := range bulkWriteException.WriteErrors {
+		result.Errors[indexes[writeError.Index]] = writeError
+		if !b.unordered {
+			executed = min(executed, writeError.Index+1)
+		}
+	}
+	if res != nil {
+		result.InsertedCount = res.InsertedCount
+		result.MatchedCount = res.MatchedCount
+		result.ModifiedCount = res.ModifiedCount
+		result.DeletedCount = res.DeletedCount
+		result.UpsertedCount = res.UpsertedCount
+		for index, id := range res.UpsertedIDs {
+			result.UpsertedIDs[indexes[index]] = id
+		}
+	}
+	for i, writeModel := range writeModels[:executed] {
+		index := indexes[i]
+		err := result.Errors[index]
+		if insertModel, ok := writeModel.(*mongo.InsertOneModel); ok && err == nil {
+			result.InsertedIDs[index] = utils.Cast[bson.M](insertModel.Document)["_id"]
+		}
+		if after := b.operations[index].after; after != nil {
+			after(b.model, err)
+		}
+	}
+	return result
+}
+
+func (b Bulk[T]) add(operation bulkOperation[T]) Bulk[T] {
+	b.operations = append(slices.Clip(b.operations), operation)
+	return b
+}
+
+// Converts a recovered panic value into an error.
+func toError(value any) error {
+	if err, ok := value.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", value)
+}
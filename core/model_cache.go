@@ -0,0 +1,190 @@
+package elemental
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Caches the results of this query in the configured cache store for the given duration.
+// A ttl of zero keeps the results until they are invalidated or evicted.
+// Cached results are invalidated as soon as a document of this collection, or of a collection looked up by the query,
+// is written through the Create, Update, Replace, Delete or Bulk executors of a model.
+// Queries which run within a session are never cached so that they always see the writes of their transaction.
+//
+// Usage:
+//
+//	UserModel.Find(primitive.M{"age": 30}).Cache(time.Minute).ExecTT()
+func (m Model[T]) Cache(ttl time.Duration) Model[T] {
+	m.cacheTTL = &ttl
+	return m
+}
+
+// Runs the given pipeline, serving the documents from the cache store if they have already been fetched.
+// The documents are held as raw BSON so that every execution decodes its own copy of the results.
+func (m Model[T]) cachedAggregate(ctx context.Context, store CacheStore, pipeline mongo.Pipeline) (*mongo.Cursor, error) {
+	key := m.cacheKey(store, pipeline)
+	if documents, ok := store.Get(key); ok {
+		if raw, ok := documents.([]bson.Raw); ok {
+			return mongo.NewCursorFromDocuments(lo.ToAnySlice(raw), nil, nil)
+		}
+	}
+	cursor, err := m.Collection().Aggregate(ctx, pipeline, m.aggregateOptions())
+	if err != nil {
+		return nil, err
+	}
+	var documents []bson.Raw
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	store.Set(key, documents, *m.cacheTTL)
+	return mongo.NewCursorFromDocuments(lo.ToAnySlice(documents), nil, nil)
+}
+
+// Derives the cache key of a pipeline from its canonical form, the connection, database and read preference it runs with
+// and the current version of every collection it reads from.
+func (m Model[T]) cacheKey(store CacheStore, pipeline mongo.Pipeline) string {
+	canonical := canonicalize(pipeline)
+	collection := m.Collection()
+	readPreference := ""
+	if preference := lo.CoalesceOrEmpty(m.readPreference, m.Schema.Options.ReadPreference); preference != nil {
+		readPreference = preference.String()
+	}
+	versions := []string{cacheVersion(store, collection.Database().Name(), collection.Name())}
+	for _, from := range lookedUpCollections(canonical) {
+		versions = append(versions, cacheVersion(store, collection.Database().Name(), from))
+	}
+	hash := sha256.New()
+	hash.Write(lo.Must(bson.MarshalExtJSON(bson.D{
+		{Key: "connection", Value: lo.FromPtr(lo.CoalesceOrEmpty(m.temporaryConnection, &m.Schema.Options.Connection))},
+		{Key: "database", Value: collection.Database().Name()},
+		{Key: "collection", Value: collection.Name()},
+		{Key: "readPreference", Value: readPreference},
+		{Key: "versions", Value: versions},
+		{Key: "pipeline", Value: canonical},
+		{Key: "hint", Value: canonicalize(m.queryOptions.hint)},
+	}, true, false)))
+	return "elemental:cache:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// Invalidates all cached results which depend on the collection of this model.
+// Should be invoked by every executor which writes to the collection.
+func (m Model[T]) invalidateCache() {
+	store := currentCacheStore()
+	if store == nil {
+		return
+	}
+	collection := m.Collection()
+	store.Delete(cacheVersionKey(collection.Database().Name(), collection.Name()))
+}
+
+func cacheVersionKey(database, collection string) string {
+	return "elemental:cache-version:" + database + "." + collection
+}
+
+// Returns the current version token of a collection, generating a new one if the collection has been invalidated since.
+// Since a token is never reused, entries cached under a previous version can no longer be reached once it has been removed.
+func cacheVersion(store CacheStore, database, collection string) string {
+	key := cacheVersionKey(database, collection)
+	if version, ok := store.Get(key); ok {
+		return version.(string)
+	}
+	token := make([]byte, 16)
+	lo.Must(rand.Read(token))
+	version := hex.EncodeToString(token)
+	store.Set(key, version, 0)
+	return version
+}
+
+// Collects the names of all collections which are read by the lookup and union stages of a canonicalized pipeline.
+func lookedUpCollections(value any) []string {
+	var collections []string
+	switch v := value.(type) {
+	case bson.D:
+		for _, e := range v {
+			switch e.Key {
+			case "$lookup", "$graphLookup":
+				if from, ok := canonicalLookup(e.Value, "from").(string); ok {
+					collections = append(collections, from)
+				}
+			case "$unionWith":
+				if coll, ok := e.Value.(string); ok {
+					collections = append(collections, coll)
+				} else if coll, ok := canonicalLookup(e.Value, "coll").(string); ok {
+					collections = append(collections, coll)
+				}
+			}
+			collections = append(collections, lookedUpCollections(e.Value)...)
+		}
+	case bson.A:
+		for _, item := range v {
+			collections = append(collections, lookedUpCollections(item)...)
+		}
+	}
+	return lo.Uniq(collections)
+}
+
+func canonicalLookup(value any, key string) any {
+	if d, ok := value.(bson.D); ok {
+		for _, e := range d {
+			if e.Key == key {
+				return e.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Converts a value into a form which always marshals to the same bytes.
+// Maps are converted into documents with sorted keys since their iteration order is random, whereas
+// ordered documents, slices and all other values retain their order.
+func canonicalize(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bson.D:
+		return bson.D(lo.Map(v, func(e bson.E, _ int) bson.E {
+			return bson.E{Key: e.Key, Value: canonicalize(e.Value)}
+		}))
+	}
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		keys := lo.Map(val.MapKeys(), func(k reflect.Value, _ int) string { return k.String() })
+		slices.Sort(keys)
+		return bson.D(lo.Map(keys, func(k string, _ int) bson.E {
+			return bson.E{Key: k, Value: canonicalize(val.MapIndex(reflect.ValueOf(k).Convert(val.Type().Key())).Interface())}
+		}))
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 { // Binary data and raw documents
+			return value
+		}
+		if val.Type().Elem() == reflect.TypeOf(bson.E{}) { // Named document types such as primitive.D
+			return canonicalize(val.Convert(reflect.TypeOf(bson.D{})).Interface())
+		}
+		result := make(bson.A, val.Len())
+		for i := range result {
+			result[i] = canonicalize(val.Index(i).Interface())
+		}
+		return result
+	case reflect.Ptr:
+		if val.IsNil() {
+			return nil
+		}
+		if val.Elem().Kind() == reflect.Map || val.Elem().Kind() == reflect.Slice {
+			return canonicalize(val.Elem().Interface())
+		}
+	}
+	return value
+}
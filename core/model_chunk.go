@@ -0,0 +1,251 @@
+package elemental
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The collection in which chunk checkpoints are saved unless another one is specified.
+const DefaultCheckpointCollection = "elemental_checkpoints"
+
+type ChunkOptions struct {
+	Workers              int    // The number of batches processed concurrently. Defaults to 1
+	Checkpoint           string // The name under which progress is saved so that an interrupted run resumes where it stopped. It is scoped to the collection of the model and to the tenant of the query. Progress is not saved if empty
+	CheckpointCollection string // The collection in which progress is saved. Defaults to DefaultCheckpointCollection
+}
+
+type ChunkResult struct {
+	Batches   int64 `json:"batches"`   // The number of batches processed by this run
+	Documents int64 `json:"documents"` // The number of documents processed by this run
+}
+
+type chunkBatch[T any] struct {
+	index    int64
+	docs     []T
+	position any // The checkpoint position once this batch and all batches before it have been processed
+}
+
+// Extends the query to walk through all matching documents in batches of the given size, passing each batch to the given function.
+// The documents are fetched with skip and limit in the order of any preceding sort stage, or by _id otherwise.
+// Since a document which stops matching the query shifts every document after it, use ChunkByID if the function modifies the matched fields.
+// Processing stops at the first error returned by the function, and the query panics with that error.
+// The result of the query is a ChunkResult.
+//
+// Usage:
+//
+//	UserModel.Where("age").GreaterThan(30).Chunk(500, func(users []User) error {
+//		return nil
+//	}).Exec()
+func (m Model[T]) Chunk(size int64, fn func(batch []T) error, opts ...ChunkOptions) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		pipeline, sort := m.splitSortStages()
+		if _, ok := lo.Find(sort, func(e bson.E) bool { return e.Key == "_id" }); !ok {
+			sort = append(sort, bson.E{Key: "_id", Value: 1})
+		}
+		return m.processChunks(ctx, size, fn, lo.FirstOrEmpty(opts), int64(0), func(position any) ([]bson.Raw, any) {
+			offset := cast.ToInt64(position)
+			stages := append(slices.Clone(pipeline),
+				bson.D{{Key: "$sort", Value: sort}},
+				bson.D{{Key: "$skip", Value: offset}},
+				bson.D{{Key: "$limit", Value: size}},
+			)
+			docs := m.fetchChunk(ctx, stages)
+			return docs, offset + int64(len(docs))
+		})
+	}
+	return m
+}
+
+// Extends the query to walk through all matching documents in batches of the given size, passing each batch to the given function.
+// Each batch is fetched by the _id which follows the last one of the previous batch, hence documents are always visited in ascending order of _id
+// and any preceding sort stage is ignored. Unlike Chunk, this remains correct while the matched documents are modified or removed.
+// Processing stops at the first error returned by the function, and the query panics with that error.
+// The result of the query is a ChunkResult.
+//
+// Usage:
+//
+//	UserModel.Where("retired", false).ChunkByID(500, func(users []User) error {
+//		return nil
+//	}, elemental.ChunkOptions{Workers: 4, Checkpoint: "retire-users"}).Exec()
+func (m Model[T]) ChunkByID(size int64, fn func(batch []T) error, opts ...ChunkOptions) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		pipeline, _ := m.splitSortStages()
+		return m.processChunks(ctx, size, fn, lo.FirstOrEmpty(opts), nil, func(position any) ([]bson.Raw, any) {
+			stages := slices.Clone(pipeline)
+			if position != nil {
+				stages = append(stages, bson.D{{Key: "$match", Value: primitive.M{"_id": primitive.M{"$gt": position}}}})
+			}
+			stages = append(stages,
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+				bson.D{{Key: "$limit", Value: size}},
+			)
+			docs := m.fetchChunk(ctx, stages)
+			if len(docs) == 0 {
+				return docs, position
+			}
+			var last struct {
+				ID any `bson:"_id"`
+			}
+			lo.Must0(bson.Unmarshal(docs[len(docs)-1], &last))
+			return docs, last.ID
+		})
+	}
+	return m
+}
+
+func (m Model[T]) fetchChunk(ctx context.Context, stages mongo.Pipeline) []bson.Raw {
+	m.cacheTTL = nil
+	var docs []bson.Raw
+	cursor := lo.Must(m.aggregate(ctx, stages))
+	m.checkConditionsAndPanicForErr(cursor.All(ctx, &docs))
+	return docs
+}
+
+// Fetches batches one after another starting from the given position, and hands them over to the configured number of workers.
+// The checkpoint only ever advances past a batch once all batches before it have been processed as well,
+// so that resuming never skips a batch which was still being processed when the run was interrupted.
+func (m Model[T]) processChunks(ctx context.Context, size int64, fn func(batch []T) error, opts ChunkOptions,
+	start any, fetch func(position any) ([]bson.Raw, any)) ChunkResult {
+	if size <= 0 {
+		panic(ErrInvalidChunkSize)
+	}
+	var checkpoints *mongo.Collection
+	position := start
+	checkpoint := m.Collection().Name() + ":" + opts.Checkpoint // Namespaced so that models sharing a checkpoint name do not overwrite each other
+	if m.tenant != nil {
+		checkpoint = m.Collection().Name() + ":" + fmt.Sprint(m.tenant) + ":" + opts.Checkpoint // Tenants sharing a collection keep their own progress
+	}
+	if opts.Checkpoint != "" {
+		checkpoints = m.Collection().Database().Collection(lo.CoalesceOrEmpty(opts.CheckpointCollection, DefaultCheckpointCollection))
+		var saved struct {
+			Position any `bson:"position"`
+		}
+		err := checkpoints.FindOne(ctx, primitive.M{"_id": checkpoint}).Decode(&saved)
+		if err == nil {
+			position = saved.Position
+		} else if err != mongo.ErrNoDocuments {
+			panic(err)
+		}
+	}
+
+	// Progress is saved even once processing has been cancelled, since the batches which completed before still count
+	checkpointCtx := context.WithoutCancel(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		failure   any
+		result    ChunkResult
+		completed = make(map[int64]any)
+		nextIndex int64
+	)
+	fail := func(r any) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failure == nil {
+			failure = r
+		}
+		cancel()
+	}
+	complete := func(batch chunkBatch[T]) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Batches++
+		result.Documents += int64(len(batch.docs))
+		completed[batch.index] = batch.position
+		advanced := false
+		var watermark any
+		for {
+			p, ok := completed[nextIndex]
+			if !ok {
+				break
+			}
+			delete(completed, nextIndex)
+			nextIndex++
+			watermark, advanced = p, true
+		}
+		if advanced && checkpoints != nil {
+			lo.Must(checkpoints.UpdateOne(checkpointCtx, primitive.M{"_id": checkpoint},
+				primitive.M{"$set": primitive.M{"position": watermark, "updatedAt": time.Now()}},
+				options.Update().SetUpsert(true)))
+		}
+	}
+
+	batches := make(chan chunkBatch[T])
+	var wg sync.WaitGroup
+	for range max(opts.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if ctx.Err() != nil {
+					continue
+				}
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							fail(r)
+						}
+					}()
+					if err := fn(batch.docs); err != nil {
+						fail(err)
+						return
+					}
+					complete(batch)
+				}()
+			}
+		}()
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fail(r)
+			}
+		}()
+		for index := int64(0); ctx.Err() == nil; index++ {
+			raw, next := fetch(position)
+			if len(raw) == 0 {
+				return
+			}
+			docs := lo.Map(raw, func(r bson.Raw, _ int) T {
+				var doc T
+				lo.Must0(bson.Unmarshal(r, &doc))
+				return doc
+			})
+			select {
+			case batches <- chunkBatch[T]{index: index, docs: docs, position: next}:
+			case <-ctx.Done():
+				return
+			}
+			position = next
+			if int64(len(raw)) < size {
+				return
+			}
+		}
+	}()
+	close(batches)
+	wg.Wait()
+
+	if failure != nil {
+		panic(failure)
+	}
+	if err := ctx.Err(); err != nil {
+		panic(err)
+	}
+	if checkpoints != nil {
+		lo.Must(checkpoints.DeleteOne(ctx, primitive.M{"_id": checkpoint}))
+	}
+	return result
+}
@@ -0,0 +1,260 @@
+package elemental
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type QueryLogEntry struct {
+	Model      string         // The name of the model which executed the operation
+	Database   string         // The database the operation was executed against
+	Collection string         // The collection the operation was executed against
+	Operation  string         // The name of the method which built the executed operation. E.g. Find, UpdateOne
+	Pipeline   mongo.Pipeline // The pipeline of the query as it was sent, including the filters of global scopes and the tenant. Empty for operations which do not run an aggregation, such as writes
+	Shell      string         // The sent pipeline rendered as a mongosh aggregate command. Empty for operations which do not run an aggregation
+	Duration   time.Duration  // The time taken to execute the operation, including decoding its results
+	Error      any            // The value the operation panicked with, if any
+}
+
+var queryLogger atomic.Pointer[func(entry QueryLogEntry)]
+
+// Logs every operation executed by any model along with its duration.
+// Optionally accepts a custom logger, otherwise each operation is printed with log.Printf.
+func LogQueries(logger ...func(entry QueryLogEntry)) {
+	fn := lo.FirstOr(logger, func(entry QueryLogEntry) {
+		status := "ok"
+		if entry.Error != nil {
+			status = fmt.Sprintf("failed: %v", entry.Error)
+		}
+		log.Printf("[elemental] %s.%s %s (%s, %s)", entry.Model, entry.Operation, entry.Shell, entry.Duration, status)
+	})
+	queryLogger.Store(&fn)
+}
+
+// Stops logging executed operations.
+func DisableQueryLogging() {
+	queryLogger.Store(nil)
+}
+
+// Returns a copy of the stages this query has been built up with so far.
+// Modifying the returned pipeline has no effect on the query.
+func (m Model[T]) Pipeline() mongo.Pipeline {
+	return lo.Map(m.pipeline, func(stage bson.D, _ int) bson.D {
+		return slices.Clone(stage)
+	})
+}
+
+// Renders the pipeline of this query as a canonical Extended JSON array.
+// Map keys are sorted so that the same query always renders to the same string.
+func (m Model[T]) String() string {
+	stages := lo.Map(stripPopulateScopes(m.pipeline), func(stage bson.D, _ int) string {
+		return string(lo.Must(bson.MarshalExtJSON(canonicalize(stage), true, false)))
+	})
+	return "[" + strings.Join(stages, ",") + "]"
+}
+
+// Renders this query as an aggregate command which can be pasted into mongosh as is.
+// Query options such as hints and comments are included as the options of the command.
+//
+// Usage:
+//
+//	fmt.Println(UserModel.Where("age").GreaterThan(30).ToShell())
+//	// db.users.aggregate([{ $match: { age: { $gt: 30 } } }])
+func (m Model[T]) ToShell() string {
+	return m.toShell(stripPopulateScopes(m.pipeline))
+}
+
+// Renders the given pipeline as an aggregate command on the collection of this query along with its options.
+func (m Model[T]) toShell(pipeline mongo.Pipeline) string {
+	collection := lo.FromPtr(lo.CoalesceOrEmpty(m.temporaryCollection, &m.Schema.Options.Collection))
+	target := "db." + collection
+	if !shellIdentifier.MatchString(collection) {
+		target = fmt.Sprintf("db.getCollection(%s)", shellString(collection))
+	}
+	arguments := []string{shellValue(roundTrip(canonicalize(pipeline)))}
+	opts := bson.D{}
+	if m.queryOptions.hint != nil {
+		opts = append(opts, bson.E{Key: "hint", Value: m.queryOptions.hint})
+	}
+	if m.queryOptions.maxTime != nil {
+		opts = append(opts, bson.E{Key: "maxTimeMS", Value: int(m.queryOptions.maxTime.Milliseconds())})
+	}
+	if m.queryOptions.allowDiskUse != nil {
+		opts = append(opts, bson.E{Key: "allowDiskUse", Value: *m.queryOptions.allowDiskUse})
+	}
+	if m.queryOptions.comment != nil {
+		opts = append(opts, bson.E{Key: "comment", Value: *m.queryOptions.comment})
+	}
+	if m.queryOptions.batchSize != nil {
+		opts = append(opts, bson.E{Key: "batchSize", Value: *m.queryOptions.batchSize})
+	}
+	if len(opts) > 0 {
+		arguments = append(arguments, shellValue(roundTrip(canonicalize(opts))))
+	}
+	return fmt.Sprintf("%s.aggregate(%s)", target, strings.Join(arguments, ", "))
+}
+
+// Records the first pipeline sent by an executor, so that the query logger reports exactly what was run.
+type pipelineRecorder struct {
+	once     sync.Once
+	pipeline mongo.Pipeline
+	recorded bool
+}
+
+type pipelineRecorderKey struct{}
+
+// Records the given pipeline in the recorder of the given context, if it has not recorded one yet.
+func recordPipeline(ctx context.Context, pipeline mongo.Pipeline) {
+	if recorder, ok := ctx.Value(pipelineRecorderKey{}).(*pipelineRecorder); ok {
+		recorder.once.Do(func() {
+			if pipeline == nil {
+				pipeline = mongo.Pipeline{}
+			}
+			recorder.pipeline = pipeline
+			recorder.recorded = true
+		})
+	}
+}
+
+// Runs the executor of this query within the tenant of the given context, reporting it to the query logger if one has been set.
+// The pipeline is only reported for executors which run an aggregation, since writes are not sent as a pipeline.
+func (m Model[T]) execute(ctx context.Context) any {
+	m = m.withTenant(ctx)
+	logger := queryLogger.Load()
+	if logger == nil {
+		return m.executor(m, ctx)
+	}
+	recorder := &pipelineRecorder{}
+	ctx = context.WithValue(ctx, pipelineRecorderKey{}, recorder)
+	start := time.Now()
+	defer func() {
+		r := recover()
+		collection := m.Collection()
+		entry := QueryLogEntry{
+			Model:      m.Name,
+			Database:   collection.Database().Name(),
+			Collection: collection.Name(),
+			Operation:  executorName(m.executor),
+			Duration:   time.Since(start),
+			Error:      r,
+		}
+		if recorder.recorded {
+			entry.Pipeline = recorder.pipeline
+			entry.Shell = m.toShell(recorder.pipeline)
+		}
+		(*logger)(entry)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	return m.executor(m, ctx)
+}
+
+// Derives the name of the method which built an executor from the name of its closure.
+// E.g. github.com/elcengine/elemental/core.Model[...].UpdateOne.func1 becomes UpdateOne
+func executorName(executor any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(executor).Pointer()).Name()
+	name = name[strings.LastIndex(name, "]")+1:]
+	name = strings.TrimPrefix(name, ".")
+	if i := strings.Index(name, "."); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+var shellIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Converts a canonicalized value into the generic BSON types by marshalling it, so that structs and
+// custom types are rendered exactly as they would be sent to the server.
+func roundTrip(value any) any {
+	var result bson.D
+	lo.Must0(bson.Unmarshal(lo.Must(bson.Marshal(bson.D{{Key: "v", Value: value}})), &result))
+	return result[0].Value
+}
+
+func shellString(s string) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	lo.Must0(encoder.Encode(s))
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// Renders a value decoded from BSON as mongosh syntax, using the shell constructors for the types JSON cannot express.
+func shellValue(value any) string {
+	switch v := value.(type) {
+	case nil, primitive.Null:
+		return "null"
+	case primitive.D:
+		if len(v) == 0 {
+			return "{}"
+		}
+		fields := lo.Map(v, func(e primitive.E, _ int) string {
+			key := e.Key
+			if !shellIdentifier.MatchString(key) {
+				key = shellString(key)
+			}
+			return key + ": " + shellValue(e.Value)
+		})
+		return "{ " + strings.Join(fields, ", ") + " }"
+	case primitive.A:
+		return "[" + strings.Join(lo.Map(v, func(item any, _ int) string { return shellValue(item) }), ", ") + "]"
+	case string:
+		return shellString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return fmt.Sprintf("NumberLong(%d)", v)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case primitive.ObjectID:
+		return fmt.Sprintf("ObjectId(%q)", v.Hex())
+	case primitive.DateTime:
+		return fmt.Sprintf("ISODate(%q)", v.Time().UTC().Format("2006-01-02T15:04:05.000Z"))
+	case primitive.Decimal128:
+		return fmt.Sprintf("NumberDecimal(%q)", v.String())
+	case primitive.Timestamp:
+		return fmt.Sprintf("Timestamp({ t: %d, i: %d })", v.T, v.I)
+	case primitive.Binary:
+		return fmt.Sprintf("BinData(%d, %q)", v.Subtype, base64.StdEncoding.EncodeToString(v.Data))
+	case primitive.Regex:
+		return "/" + strings.ReplaceAll(v.Pattern, "/", `\/`) + "/" + v.Options
+	case primitive.Undefined:
+		return "undefined"
+	case primitive.MinKey:
+		return "MinKey()"
+	case primitive.MaxKey:
+		return "MaxKey()"
+	}
+	ext := string(lo.Must(bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)))
+	return strings.TrimSuffix(strings.TrimPrefix(ext, `{"v":`), "}")
+}
@@ -0,0 +1,56 @@
+package elemental
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DistinctValue[V any] struct {
+	Value V     `json:"value" bson:"_id"`   // The distinct value
+	Count int64 `json:"count" bson:"count"` // The number of occurrences of the value across all matched documents
+}
+
+// DistinctT returns the distinct values of the given field decoded into the given value type, sorted in ascending order.
+// Array fields, as well as arrays along a dotted path, are unwound so that each of their elements counts as a value of its own.
+// Documents in which the field is missing or null are left out. Any filters should be added to the query beforehand.
+//
+// Usage:
+//
+//	ids := elemental.DistinctT[primitive.ObjectID](BookModel.Where("published", true), "author")
+//	weapons := elemental.DistinctT[string](UserModel, "weapons")
+func DistinctT[V any, T any](m Model[T], field string, ctx ...context.Context) []V {
+	m = m.distinctStages(field).Stage(bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}})
+	return lo.Map(AggregateInto[[]DistinctValue[V]](m, ctx...), func(v DistinctValue[V], _ int) V {
+		return v.Value
+	})
+}
+
+// DistinctWithCount returns the distinct values of the given field along with the number of times each value occurs.
+// The values are sorted by their count in descending order and then by the value itself.
+// Array fields are unwound in the same way as in DistinctT.
+//
+// Usage:
+//
+//	elemental.DistinctWithCount[string](UserModel, "occupation")
+//	// [{Value: "Mage", Count: 2}, {Value: "Witcher", Count: 2}, {Value: "General", Count: 1}]
+func DistinctWithCount[V any, T any](m Model[T], field string, ctx ...context.Context) []DistinctValue[V] {
+	m = m.distinctStages(field).Stage(bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}})
+	return lo.CoalesceSliceOrEmpty(AggregateInto[[]DistinctValue[V]](m, ctx...))
+}
+
+// Extends the query with the stages which unwind every segment of the given field and group the documents by its values.
+func (m Model[T]) distinctStages(field string) Model[T] {
+	segments := strings.Split(strings.TrimPrefix(field, "$"), ".")
+	for i := range segments {
+		m.pipeline = append(m.pipeline, bson.D{{Key: "$unwind", Value: fieldPath(strings.Join(segments[:i+1], "."))}})
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$group", Value: primitive.M{
+		"_id":   fieldPath(strings.Join(segments, ".")),
+		"count": primitive.M{"$sum": 1},
+	}}})
+	return m
+}
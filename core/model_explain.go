@@ -0,0 +1,174 @@
+package elemental
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ExplainVerbosity string
+
+const (
+	ExplainQueryPlanner      ExplainVerbosity = "queryPlanner"      // Only runs the query optimizer and reports the winning plan
+	ExplainExecutionStats    ExplainVerbosity = "executionStats"    // Runs the winning plan and reports its execution statistics
+	ExplainAllPlansExecution ExplainVerbosity = "allPlansExecution" // Same as executionStats but includes statistics of the rejected plans as well
+)
+
+type ExplainResult struct {
+	WinningPlan    primitive.M   `json:"winningPlan"`    // The plan selected by the query optimizer
+	Stages         []string      `json:"stages"`         // The stages of the winning plan from the outermost to the innermost. E.g. FETCH, IXSCAN
+	IndexesUsed    []string      `json:"indexesUsed"`    // The names of the indexes used by the winning plan
+	CollectionScan bool          `json:"collectionScan"` // Whether the winning plan scans the entire collection
+	IndexScan      bool          `json:"indexScan"`      // Whether the winning plan scans an index
+	DocsExamined   int64         `json:"docsExamined"`   // The number of documents examined. Only available with executionStats or higher verbosity
+	KeysExamined   int64         `json:"keysExamined"`   // The number of index keys examined. Only available with executionStats or higher verbosity
+	DocsReturned   int64         `json:"docsReturned"`   // The number of documents returned. Only available with executionStats or higher verbosity
+	ExecutionTime  time.Duration `json:"executionTime"`  // The time taken to execute the query. Only available with executionStats or higher verbosity
+	Raw            primitive.M   `json:"raw"`            // The raw output of the explain command
+}
+
+type CollectionScanWarningOptions struct {
+	Threshold int64                            // The minimum estimated number of documents in a collection for a collection scan to be reported
+	Logger    func(format string, args ...any) // Custom logger to report collection scans with. Defaults to log.Printf
+}
+
+var collectionScanWarnings atomic.Pointer[CollectionScanWarningOptions]
+
+// Logs a warning for every executed query which performs a collection scan on a collection larger than the given threshold.
+// Every query is explained before it is executed, so this is meant to be used only during development.
+func WarnOnCollectionScans(opts CollectionScanWarningOptions) {
+	if opts.Logger == nil {
+		opts.Logger = log.Printf
+	}
+	collectionScanWarnings.Store(&opts)
+}
+
+// Stops logging warnings for queries which perform a collection scan.
+func DisableCollectionScanWarnings() {
+	collectionScanWarnings.Store(nil)
+}
+
+// Explain runs the query pipeline with the explain command and returns a summary of the execution plan.
+// The pipeline is explained exactly as it would be executed, within the tenant of the given context.
+func (m Model[T]) Explain(verbosity ExplainVerbosity, ctx ...context.Context) ExplainResult {
+	c := utils.CtxOrDefault(ctx)
+	m = m.withTenant(c)
+	return lo.Must(m.explain(c, m.finalPipeline(c, m.pipeline), verbosity))
+}
+
+func (m Model[T]) explain(ctx context.Context, pipeline mongo.Pipeline, verbosity ExplainVerbosity) (ExplainResult, error) {
+	var raw primitive.M
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+	collection := m.Collection()
+	command := bson.D{
+		{Key: "aggregate", Value: collection.Name()},
+		{Key: "pipeline", Value: pipeline},
+		{Key: "cursor", Value: bson.D{}},
+	}
+	if m.queryOptions.hint != nil {
+		command = append(command, bson.E{Key: "hint", Value: m.queryOptions.hint})
+	}
+	err := collection.Database().RunCommand(ctx, bson.D{
+		{Key: "explain", Value: command},
+		{Key: "verbosity", Value: verbosity},
+	}).Decode(&raw)
+	if err != nil {
+		return ExplainResult{}, err
+	}
+	result := ExplainResult{Raw: raw}
+	if queryPlanner, ok := findExplainKey(raw, "queryPlanner").(primitive.M); ok {
+		result.WinningPlan = utils.Cast[primitive.M](queryPlanner["winningPlan"])
+		walkExplainPlan(result.WinningPlan, func(plan primitive.M) {
+			stage := cast.ToString(plan["stage"])
+			if stage == "" {
+				return
+			}
+			result.Stages = append(result.Stages, stage)
+			switch stage {
+			case "COLLSCAN":
+				result.CollectionScan = true
+			case "IXSCAN", "DISTINCT_SCAN", "COUNT_SCAN", "EXPRESS_IXSCAN":
+				result.IndexScan = true
+			}
+			if index := cast.ToString(plan["indexName"]); index != "" && !slices.Contains(result.IndexesUsed, index) {
+				result.IndexesUsed = append(result.IndexesUsed, index)
+			}
+		})
+	}
+	if stats, ok := findExplainKey(raw, "executionStats").(primitive.M); ok {
+		result.DocsExamined = cast.ToInt64(stats["totalDocsExamined"])
+		result.KeysExamined = cast.ToInt64(stats["totalKeysExamined"])
+		result.DocsReturned = cast.ToInt64(stats["nReturned"])
+		result.ExecutionTime = time.Duration(cast.ToInt64(stats["executionTimeMillis"])) * time.Millisecond
+	}
+	return result, nil
+}
+
+// Explains the given pipeline and logs a warning if it performs a collection scan on a collection larger than the configured threshold.
+// Any errors are ignored since this is a development aid which should never interfere with the query itself.
+func (m Model[T]) warnOnCollectionScan(ctx context.Context, pipeline mongo.Pipeline) {
+	opts := collectionScanWarnings.Load()
+	if opts == nil || mongo.SessionFromContext(ctx) != nil {
+		return // Explain is not allowed within transactions
+	}
+	result, err := m.explain(ctx, pipeline, ExplainQueryPlanner)
+	if err != nil || !result.CollectionScan {
+		return
+	}
+	if count := m.EstimatedDocumentCount(ctx); count > opts.Threshold {
+		opts.Logger("elemental: query on collection %s of model %s performed a collection scan over ~%d documents. Pipeline: %v",
+			m.Collection().Name(), m.Name, count, pipeline)
+	}
+}
+
+// Finds the first value with the given key within a nested explain output.
+func findExplainKey(node any, key string) any {
+	switch v := node.(type) {
+	case primitive.M:
+		if value, ok := v[key]; ok {
+			return value
+		}
+		for _, child := range v {
+			if value := findExplainKey(child, key); value != nil {
+				return value
+			}
+		}
+	case primitive.A:
+		for _, child := range v {
+			if value := findExplainKey(child, key); value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// Visits every stage of a plan tree from the outermost to the innermost.
+func walkExplainPlan(plan primitive.M, visit func(plan primitive.M)) {
+	if plan == nil {
+		return
+	}
+	visit(plan)
+	if queryPlan, ok := plan["queryPlan"].(primitive.M); ok { // Slot based execution engine
+		walkExplainPlan(queryPlan, visit)
+	}
+	if inputStage, ok := plan["inputStage"].(primitive.M); ok {
+		walkExplainPlan(inputStage, visit)
+	}
+	if inputStages, ok := plan["inputStages"].(primitive.A); ok {
+		for _, stage := range inputStages {
+			walkExplainPlan(utils.Cast[primitive.M](stage), visit)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package elemental
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DataFormat string
+
+const (
+	FormatJSONL        DataFormat = "jsonl" // One JSON document per line, encoded with the json tags of the model type
+	FormatCSV          DataFormat = "csv"   // A header row followed by one row per document, with a column per field of the model type
+	FormatExtendedJSON DataFormat = "ejson" // One canonical Extended JSON document per line, as written by mongoexport
+)
+
+// The number of documents inserted at once by Import unless another batch size is specified.
+const DefaultImportBatchSize = 1000
+
+// The maximum size of a single line read by Import, which is comfortably above the maximum size of a BSON document.
+const maxImportLineSize = 64 * 1024 * 1024
+
+type ImportOptions struct {
+	BatchSize   int  // The number of documents inserted at once. Defaults to DefaultImportBatchSize
+	StopOnError bool // Whether to stop reading further rows once a row has failed. Rows read before it are still inserted
+}
+
+type ImportResult struct {
+	Inserted int64         `json:"inserted"` // The number of documents inserted
+	Failed   []ImportError `json:"failed"`   // The rows which could not be parsed, validated or inserted, in the order they were read
+}
+
+type ImportError struct {
+	Row int   `json:"row"` // The 1-based position of the row among all rows read, not counting blank lines and the CSV header
+	Err error `json:"error"`
+}
+
+func (e ImportError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e ImportError) Unwrap() error {
+	return e.Err
+}
+
+// Extends the query to stream every document it matches into the given writer in the given format.
+// CSV columns are named after the csv tag of each field, falling back to its bson name. Nested documents and arrays are written as relaxed Extended JSON.
+// Models which are maps have a column for every field found in any of the matched documents. The header is written even if no document matches.
+// The result of the query is the number of documents written.
+//
+// Usage:
+//
+//	UserModel.Where("age").GreaterThan(30).Export(os.Stdout, elemental.FormatCSV).Exec()
+func (m Model[T]) Export(w io.Writer, format DataFormat) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		if !slices.Contains([]DataFormat{FormatJSONL, FormatCSV, FormatExtendedJSON}, format) {
+			panic(ErrUnsupportedFormat)
+		}
+		var csvWriter *csv.Writer
+		var columns []dataColumn
+		if format == FormatCSV {
+			sample := reflect.ValueOf(new(T)).Elem()
+			columns = dataColumns(sample)
+			if sample.Kind() == reflect.Map {
+				columns = lo.Map(m.documentFields(ctx), func(field string, _ int) dataColumn { return dataColumn{name: field} })
+			}
+			csvWriter = csv.NewWriter(w)
+			if len(columns) > 0 {
+				lo.Must0(csvWriter.Write(lo.Map(columns, func(c dataColumn, _ int) string { return c.name })))
+			}
+		}
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
+		defer cursor.Close(ctx)
+		count := int64(0)
+		for cursor.Next(ctx) {
+			switch format {
+			case FormatExtendedJSON:
+				lo.Must(w.Write(append(lo.Must(bson.MarshalExtJSON(cursor.Current, true, false)), '\n')))
+			case FormatJSONL:
+				var doc T
+				m.checkConditionsAndPanicForErr(cursor.Decode(&doc))
+				lo.Must(w.Write(append(lo.Must(json.Marshal(doc)), '\n')))
+			case FormatCSV:
+				var doc T
+				m.checkConditionsAndPanicForErr(cursor.Decode(&doc))
+				value := reflect.ValueOf(&doc).Elem()
+				lo.Must0(csvWriter.Write(lo.Map(columns, func(c dataColumn, _ int) string {
+					return encodeCell(c.get(value))
+				})))
+			}
+			count++
+		}
+		m.checkConditionsAndPanicForErr(cursor.Err())
+		if csvWriter != nil {
+			csvWriter.Flush()
+			lo.Must0(csvWriter.Error())
+		}
+		return count
+	}
+	return m
+}
+
+// Extends the query to read documents in the given format from the given reader and insert them into the collection in batches.
+// Each row is validated against the model schema. Rows which fail to parse, validate or insert are reported in the result instead of failing the import,
+// whereas reading errors and all other database errors still panic. CSV rows are parsed according to the columns written by Export.
+// The result of the query is an ImportResult.
+//
+// Usage:
+//
+//	result := UserModel.Import(file, elemental.FormatJSONL).Exec().(elemental.ImportResult)
+func (m Model[T]) Import(r io.Reader, format DataFormat, opts ...ImportOptions) Model[T] {
+	importOpts := lo.FirstOrEmpty(opts)
+	batchSize := lo.Ternary(importOpts.BatchSize > 0, importOpts.BatchSize, DefaultImportBatchSize)
+	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
+		var next func() ([]byte, []string, bool)
+		var columns map[string]dataColumn
+		var header []string
+		switch format {
+		case FormatJSONL, FormatExtendedJSON:
+			scanner := bufio.NewScanner(r)
+			scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+			next = func() ([]byte, []string, bool) {
+				for scanner.Scan() {
+					if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+						return line, nil, true
+					}
+				}
+				lo.Must0(scanner.Err())
+				return nil, nil, false
+			}
+		case FormatCSV:
+			reader := csv.NewReader(r)
+			reader.FieldsPerRecord = -1
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return ImportResult{}
+			}
+			header = slices.Clone(lo.Must(record, err))
+			var sample T
+			columns = lo.KeyBy(dataColumns(reflect.ValueOf(&sample).Elem()), func(c dataColumn) string { return c.name })
+			next = func() ([]byte, []string, bool) {
+				record, err := reader.Read()
+				if errors.Is(err, io.EOF) {
+					return nil, nil, false
+				}
+				if err != nil && !errors.Is(err, csv.ErrQuote) && !errors.Is(err, csv.ErrBareQuote) {
+					panic(err)
+				}
+				return nil, lo.Ternary(err == nil, record, nil), true
+			}
+		default:
+			panic(ErrUnsupportedFormat)
+		}
+
+		result := ImportResult{Failed: []ImportError{}}
+		var batch []any
+		var batchRows []int
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			inserted, failures := m.insertImportBatch(ctx, batch, batchRows)
+			result.Inserted += inserted
+			result.Failed = append(result.Failed, failures...)
+			batch, batchRows = nil, nil
+		}
+
+		for row := 1; ; row++ {
+			line, record, ok := next()
+			if !ok {
+				break
+			}
+			document, err := func() (document bson.M, err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = toError(r)
+					}
+				}()
+				var doc T
+				switch format {
+				case FormatJSONL:
+					err = json.Unmarshal(line, &doc)
+				case FormatExtendedJSON:
+					err = bson.UnmarshalExtJSON(line, false, &doc)
+				case FormatCSV:
+					if record == nil {
+						return nil, errors.New("malformed csv record")
+					}
+					err = decodeRecord(reflect.ValueOf(&doc).Elem(), header, record, columns)
+				}
+				if err != nil {
+					return nil, err
+				}
+				return m.stampTenant(enforceSchema(m.Schema, &doc, nil)), nil
+			}()
+			if err != nil {
+				result.Failed = append(result.Failed, ImportError{Row: row, Err: err})
+				if importOpts.StopOnError {
+					break
+				}
+				continue
+			}
+			batch = append(batch, document)
+			batchRows = append(batchRows, row)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		}
+		flush()
+		return result
+	}
+	return m
+}
+
+// Inserts a batch of imported documents without stopping at the first failure,
+// and reports every document which could not be inserted against the row it was read from.
+func (m Model[T]) insertImportBatch(ctx context.Context, batch []any, rows []int) (int64, []ImportError) {
+	result, err := m.Collection().InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return int64(len(result.InsertedIDs)), nil
+	}
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		panic(err)
+	}
+	failures := lo.Map(bulkErr.WriteErrors, func(e mongo.BulkWriteError, _ int) ImportError {
+		return ImportError{Row: rows[e.Index], Err: e.WriteError}
+	})
+	return int64(len(batch) - len(failures)), failures
+}
+
+// A CSV column which maps to a field of the model type, or to a key of a map model.
+type dataColumn struct {
+	name  string
+	index []int // The index of the struct field, nil for map keys
+}
+
+func (c dataColumn) get(value reflect.Value) reflect.Value {
+	if c.index == nil {
+		return value.MapIndex(reflect.ValueOf(c.name))
+	}
+	return value.FieldByIndex(c.index)
+}
+
+// Collects the names of the top level fields of every document matched by this query in sorted order.
+// These are the CSV columns of models whose documents do not share a fixed set of fields, such as maps.
+func (m Model[T]) documentFields(ctx context.Context) []string {
+	pipeline := append(slices.Clone(m.pipeline),
+		bson.D{{Key: "$project", Value: primitive.M{
+			"_id":    0,
+			"fields": primitive.M{"$map": primitive.M{"input": primitive.M{"$objectToArray": "$$ROOT"}, "in": "$$this.k"}},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$fields"}},
+		bson.D{{Key: "$group", Value: primitive.M{"_id": "$fields"}}},
+	)
+	var fields []bson.M
+	cursor := lo.Must(m.aggregate(ctx, pipeline))
+	m.checkConditionsAndPanicForErr(cursor.All(ctx, &fields))
+	names := lo.Map(fields, func(field bson.M, _ int) string { return cast.ToString(field["_id"]) })
+	slices.Sort(names)
+	return names
+}
+
+// Returns the CSV columns of a document. Struct columns are named after the csv tag of each exported field, falling back to its bson name,
+// and fields tagged with "-" are left out. Map columns are the keys of the given document in sorted order.
+func dataColumns(value reflect.Value) []dataColumn {
+	if value.Kind() == reflect.Map {
+		keys := lo.Map(value.MapKeys(), func(k reflect.Value, _ int) string { return k.String() })
+		slices.Sort(keys)
+		return lo.Map(keys, func(k string, _ int) dataColumn { return dataColumn{name: k} })
+	}
+	var columns []dataColumn
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(lo.CoalesceOrEmpty(field.Tag.Get("csv"), field.Tag.Get("bson")), ",")[0]
+		if name == "-" {
+			continue
+		}
+		columns = append(columns, dataColumn{name: lo.CoalesceOrEmpty(name, strings.ToLower(field.Name)), index: field.Index})
+	}
+	return columns
+}
+
+var (
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// Renders a field value as a CSV cell. Scalars are written as is, whereas documents and arrays are written as relaxed Extended JSON.
+func encodeCell(value reflect.Value) string {
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+	switch {
+	case value.Type() == objectIDType:
+		return value.Interface().(primitive.ObjectID).Hex()
+	case value.Type() == timeType:
+		return value.Interface().(time.Time).Format(time.RFC3339Nano)
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value.Interface())
+	}
+	ext := string(lo.Must(bson.MarshalExtJSON(bson.D{{Key: "v", Value: value.Interface()}}, false, false)))
+	return strings.TrimSuffix(strings.TrimPrefix(ext, `{"v":`), "}")
+}
+
+// Parses a CSV record into a document. Columns which do not map to a field of the document are ignored and empty cells leave the field unset.
+func decodeRecord(doc reflect.Value, header, record []string, columns map[string]dataColumn) error {
+	if doc.Kind() == reflect.Map {
+		if doc.IsNil() {
+			doc.Set(reflect.MakeMap(doc.Type()))
+		}
+	}
+	for i, name := range header {
+		if i >= len(record) || record[i] == "" {
+			continue
+		}
+		if doc.Kind() == reflect.Map {
+			value := reflect.New(doc.Type().Elem()).Elem()
+			if err := decodeCell(value, record[i]); err != nil {
+				value.Set(reflect.ValueOf(record[i]))
+			}
+			doc.SetMapIndex(reflect.ValueOf(name).Convert(doc.Type().Key()), value)
+			continue
+		}
+		column, ok := columns[name]
+		if !ok {
+			continue
+		}
+		if err := decodeCell(doc.FieldByIndex(column.index), record[i]); err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Parses a CSV cell written by encodeCell into the given field.
+func decodeCell(field reflect.Value, cell string) error {
+	if field.Kind() == reflect.Ptr {
+		value := reflect.New(field.Type().Elem())
+		if err := decodeCell(value.Elem(), cell); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	switch {
+	case field.Type() == objectIDType:
+		id, err := primitive.ObjectIDFromHex(cell)
+		if err == nil {
+			field.Set(reflect.ValueOf(id))
+		}
+		return err
+	case field.Type() == timeType:
+		t, err := time.Parse(time.RFC3339Nano, cell)
+		if err == nil {
+			field.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+		return nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(cell)
+		if err == nil {
+			field.SetBool(v)
+		}
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err == nil {
+			field.SetInt(v)
+		}
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+		if err == nil {
+			field.SetUint(v)
+		}
+		return err
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(cell, field.Type().Bits())
+		if err == nil {
+			field.SetFloat(v)
+		}
+		return err
+	}
+	var raw bson.Raw
+	if err := bson.UnmarshalExtJSON([]byte(`{"v":`+cell+`}`), false, &raw); err != nil {
+		return err
+	}
+	return raw.Lookup("v").Unmarshal(field.Addr().Interface())
+}
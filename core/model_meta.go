@@ -8,6 +8,7 @@ import (
 	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Returns the underlying collection instance this model uses.
@@ -15,7 +16,17 @@ func (m Model[T]) Collection() *mongo.Collection {
 	connection := lo.FromPtr(lo.CoalesceOrEmpty(m.temporaryConnection, &m.Schema.Options.Connection))
 	database := lo.FromPtr(lo.CoalesceOrEmpty(m.temporaryDatabase, &m.Schema.Options.Database))
 	collection := lo.FromPtr(lo.CoalesceOrEmpty(m.temporaryCollection, &m.Schema.Options.Collection))
-	return UseDatabase(database, connection).Collection(collection)
+	opts := options.Collection()
+	if readPreference := lo.CoalesceOrEmpty(m.readPreference, m.Schema.Options.ReadPreference); readPreference != nil {
+		opts.SetReadPreference(readPreference)
+	}
+	if readConcern := lo.CoalesceOrEmpty(m.readConcern, m.Schema.Options.ReadConcern); readConcern != nil {
+		opts.SetReadConcern(readConcern)
+	}
+	if writeConcern := lo.CoalesceOrEmpty(m.writeConcern, m.Schema.Options.WriteConcern); writeConcern != nil {
+		opts.SetWriteConcern(writeConcern)
+	}
+	return UseDatabase(database, connection).Collection(collection, opts)
 }
 
 // Returns the underlying client instance this model uses
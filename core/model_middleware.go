@@ -17,6 +17,7 @@ type listener[T any] struct {
 type pre[T any] struct {
 	save              listener[T]
 	updateOne         listener[T]
+	updateMany        listener[T]
 	deleteOne         listener[T]
 	deleteMany        listener[T]
 	findOneAndUpdate  listener[T]
@@ -27,6 +28,7 @@ type pre[T any] struct {
 type post[T any] struct {
 	save              listener[T]
 	updateOne         listener[T]
+	updateMany        listener[T]
 	deleteOne         listener[T]
 	deleteMany        listener[T]
 	find              listener[T]
@@ -77,6 +79,18 @@ func (m Model[T]) PostUpdateOne(f func(result *mongo.UpdateResult, err error) bo
 	})
 }
 
+func (m Model[T]) PreUpdateMany(f func(doc any) bool) {
+	m.middleware.pre.updateMany.functions = append(m.middleware.pre.updateMany.functions, func(args ...any) bool {
+		return f(args[0])
+	})
+}
+
+func (m Model[T]) PostUpdateMany(f func(result *mongo.UpdateResult, err error) bool) {
+	m.middleware.post.updateMany.functions = append(m.middleware.post.updateMany.functions, func(args ...any) bool {
+		return f(args[0].(*mongo.UpdateResult), utils.Cast[error](args[1]))
+	})
+}
+
 func (m Model[T]) PreDeleteOne(f func(filters *primitive.M) bool) {
 	m.middleware.pre.deleteOne.functions = append(m.middleware.pre.deleteOne.functions, func(args ...any) bool {
 		return f(args[0].(*primitive.M))
@@ -1,7 +1,11 @@
 package elemental
 
 import (
+	"slices"
+
+	"github.com/elcengine/elemental/utils"
 	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -88,3 +92,83 @@ func (m Model[T]) Or() Model[T] {
 	m.orConditionActive = true
 	return m
 }
+
+// Extends the query with a "not" condition which negates the next operator applied on the given field.
+//
+// Usage:
+//
+//	UserModel.Not("age").GreaterThan(50)
+func (m Model[T]) Not(field string) Model[T] {
+	m.whereField = field
+	m.notConditionActive = true
+	return m
+}
+
+// Extends the query with a group of conditions which is or'ed with all the conditions added so far.
+// The group is built within the given function which receives a fresh query to apply the conditions on.
+//
+// Usage:
+//
+//	UserModel.Where("age", 30).Where("occupation", "Witcher").OrGroup(func(q elemental.Model[User]) elemental.Model[User] {
+//		return q.Where("age", 40).Where("occupation", "Mage")
+//	})
+func (m Model[T]) OrGroup(group func(q Model[T]) Model[T]) Model[T] {
+	return m.addGroup("$or", group)
+}
+
+// Extends the query with a group of conditions which is and'ed with all the conditions added so far.
+// This is mostly useful within other groups to nest conditions further.
+func (m Model[T]) AndGroup(group func(q Model[T]) Model[T]) Model[T] {
+	return m.addGroup("$and", group)
+}
+
+// Extends the query with a group of conditions which none of the resulting documents should satisfy.
+// The negated group is and'ed with all the conditions added so far.
+func (m Model[T]) NorGroup(group func(q Model[T]) Model[T]) Model[T] {
+	return m.addGroup("$nor", group)
+}
+
+// Combines the conditions built within the given group function with the existing filters of the query using the given operator.
+// Global scopes such as soft delete are applied separately on execution, hence they always apply to the entire query.
+func (m Model[T]) addGroup(operator string, group func(q Model[T]) Model[T]) Model[T] {
+	q := m
+	q.pipeline = nil
+	q.whereField = ""
+	q.orConditionActive = false
+	q.notConditionActive = false
+	conditions := primitive.M{}
+	for _, stage := range group(q).pipeline {
+		if stage[0].Key == "$match" {
+			conditions = lo.Assign(conditions, utils.Cast[primitive.M](utils.CastBSON[bson.M](stage)["$match"]))
+		}
+	}
+	if len(conditions) == 0 {
+		return m
+	}
+	index := lo.IndexOf(lo.Map(m.pipeline, func(stage bson.D, _ int) string { return stage[0].Key }), "$match")
+	current := primitive.M{}
+	if index != -1 {
+		current = lo.Assign(utils.Cast[primitive.M](utils.CastBSON[bson.M](m.pipeline[index])["$match"]))
+	}
+	var filters primitive.M
+	switch {
+	case operator == "$nor" && len(current) == 0:
+		filters = primitive.M{"$nor": []primitive.M{conditions}}
+	case operator == "$nor":
+		filters = primitive.M{"$and": []primitive.M{current, {"$nor": []primitive.M{conditions}}}}
+	case len(current) == 0:
+		filters = conditions
+	default:
+		filters = primitive.M{operator: []primitive.M{current, conditions}}
+	}
+	m.pipeline = slices.Clone(m.pipeline)
+	if index == -1 {
+		m.pipeline = append(m.pipeline, bson.D{{Key: "$match", Value: filters}})
+	} else {
+		m.pipeline[index] = bson.D{{Key: "$match", Value: filters}}
+	}
+	m.whereField = ""
+	m.orConditionActive = false
+	m.notConditionActive = false
+	return m
+}
@@ -0,0 +1,96 @@
+package elemental
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GeoNearOptions struct {
+	MinDistance        float64     // The minimum distance in meters from the point for a document to be included
+	MaxDistance        float64     // The maximum distance in meters from the point for a document to be included
+	Query              primitive.M // Limits the results to documents which match the given query
+	Key                string      // The geospatial indexed field to use. Required only if the collection has more than one geospatial index
+	DistanceMultiplier float64     // A factor to multiply all distances by. E.g. 0.001 to get the distance in kilometers
+	IncludeLocs        string      // The field to hold the location used to calculate the distance. Useful when the indexed field contains multiple locations
+}
+
+// The temporary field used to hold the computed distance of a near query until it is removed from the results.
+const nearDistanceField = "__elemental_distance"
+
+// Extends the query to return only documents where the given field is within the given maximum distance in meters from the given point.
+// The results are sorted from the nearest to the farthest. It optionally accepts a minimum distance in meters as well.
+// The field must have a geospatial index declared on it.
+//
+// Usage:
+//
+//	StoreModel.Where("location").Near(elemental.NewGeoPoint(79.8612, 6.9271), 5000)
+func (m Model[T]) Near(point GeoPoint, maxDistance float64, minDistance ...float64) Model[T] {
+	m = m.GeoNear(point, nearDistanceField, GeoNearOptions{
+		Key:         m.whereField,
+		MaxDistance: maxDistance,
+		MinDistance: lo.FirstOrEmpty(minDistance),
+	})
+	if len(m.pipeline) < 2 || m.pipeline[1][0].Key != "$unset" || m.pipeline[1][0].Value != nearDistanceField {
+		m.pipeline = slices.Insert(m.pipeline, 1, bson.D{{Key: "$unset", Value: nearDistanceField}})
+	}
+	return m
+}
+
+// Extends the query to return only documents where the given field lies entirely within the given shape.
+// The shape can be a GeoPolygon, a GeoCircle or any other GeoJSON geometry.
+func (m Model[T]) GeoWithin(shape any) Model[T] {
+	switch s := shape.(type) {
+	case GeoCircle:
+		return m.addToFilters("$geoWithin", primitive.M{
+			"$centerSphere": []any{s.Center.Coordinates, s.Radius / earthRadiusInMeters},
+		})
+	case *GeoCircle:
+		return m.GeoWithin(*s)
+	}
+	return m.addToFilters("$geoWithin", primitive.M{"$geometry": shape})
+}
+
+// Extends the query to return only documents where the given field intersects with the given GeoJSON geometry.
+func (m Model[T]) GeoIntersects(geometry any) Model[T] {
+	return m.addToFilters("$geoIntersects", primitive.M{"$geometry": geometry})
+}
+
+// Extends the query with a geoNear stage which returns documents sorted from the nearest to the farthest from the given point.
+// The computed distance in meters is added to each document in the given distance field.
+// Since a geoNear stage must be the first stage of a pipeline, it is always inserted at the beginning, replacing any existing one.
+func (m Model[T]) GeoNear(point GeoPoint, distanceField string, opts ...GeoNearOptions) Model[T] {
+	geoNearOpts := lo.FirstOrEmpty(opts)
+	geoNear := primitive.M{
+		"near":          point,
+		"distanceField": distanceField,
+		"spherical":     true,
+	}
+	if geoNearOpts.MinDistance > 0 {
+		geoNear["minDistance"] = geoNearOpts.MinDistance
+	}
+	if geoNearOpts.MaxDistance > 0 {
+		geoNear["maxDistance"] = geoNearOpts.MaxDistance
+	}
+	if geoNearOpts.Query != nil {
+		geoNear["query"] = geoNearOpts.Query
+	}
+	if geoNearOpts.Key != "" {
+		geoNear["key"] = geoNearOpts.Key
+	}
+	if geoNearOpts.DistanceMultiplier > 0 {
+		geoNear["distanceMultiplier"] = geoNearOpts.DistanceMultiplier
+	}
+	if geoNearOpts.IncludeLocs != "" {
+		geoNear["includeLocs"] = geoNearOpts.IncludeLocs
+	}
+	m.pipeline = slices.Clone(m.pipeline)
+	if len(m.pipeline) > 0 && m.pipeline[0][0].Key == "$geoNear" {
+		m.pipeline[0] = bson.D{{Key: "$geoNear", Value: geoNear}}
+		return m
+	}
+	m.pipeline = slices.Insert(m.pipeline, 0, bson.D{{Key: "$geoNear", Value: geoNear}})
+	return m
+}
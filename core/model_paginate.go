@@ -47,7 +47,7 @@ func (m Model[T]) Paginate(page, limit int64) Model[T] {
 	})
 	m.executor = func(m Model[T], ctx context.Context) any {
 		var results []facetResult[T]
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		m.checkConditionsAndPanicForErr(cursor.All(ctx, &results))
 		totalDocs := lo.FirstOrEmpty(results[0].Count)["count"]
 		totalPages := (totalDocs + limit - 1) / limit
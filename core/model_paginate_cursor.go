@@ -0,0 +1,224 @@
+package elemental
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"slices"
+	"strings"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CursorPaginateOptions struct {
+	SkipCount bool // Whether to skip counting the total number of documents which match the query
+}
+
+type cursorDirection string
+
+const (
+	cursorDirectionNext cursorDirection = "next"
+	cursorDirectionPrev cursorDirection = "prev"
+)
+
+type paginationCursor struct {
+	Direction cursorDirection `bson:"d"`
+	Values    bson.A          `bson:"v"`
+}
+
+var cursorSecret = lo.Must(func() ([]byte, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	return secret, err
+}())
+
+// Sets the secret used to sign pagination cursors.
+// A random secret is generated on startup, so you should set this if cursors must remain valid across restarts or multiple instances.
+func SetCursorSecret(secret []byte) {
+	cursorSecret = secret
+}
+
+// Paginate the results of the query using keyset (cursor-based) pagination.
+// The documents are sorted on the fields of any preceding sort stage with _id appended as a tie breaker.
+// Pass an empty string as the cursor to fetch the first page, and the NextCursor or PrevCursor of a previous result to move between pages.
+// The final result of the query will be a CursorPaginateResult[T] struct.
+func (m Model[T]) PaginateCursor(limit int64, after string, opts ...CursorPaginateOptions) Model[T] {
+	paginateOpts := lo.FirstOrEmpty(opts)
+	m.executor = func(m Model[T], ctx context.Context) any {
+		var current *paginationCursor
+		if after != "" {
+			current = lo.ToPtr(decodePaginationCursor(after))
+		}
+		pipeline, sort := m.splitSortStages()
+		if _, ok := lo.Find(sort, func(e bson.E) bool { return e.Key == "_id" }); !ok {
+			sort = append(sort, bson.E{Key: "_id", Value: 1})
+		}
+		reversed := current != nil && current.Direction == cursorDirectionPrev
+		stages := slices.Clone(pipeline)
+		if current != nil {
+			if len(current.Values) != len(sort) {
+				panic(ErrInvalidCursor)
+			}
+			stages = append(stages, bson.D{{Key: "$match", Value: keysetFilter(sort, current.Values, reversed)}})
+		}
+		stages = append(stages,
+			bson.D{{Key: "$sort", Value: bson.D(lo.Map(sort, func(e bson.E, _ int) bson.E {
+				if reversed {
+					return bson.E{Key: e.Key, Value: -cast.ToInt(e.Value)}
+				}
+				return e
+			}))}},
+			bson.D{{Key: "$limit", Value: limit + 1}},
+		)
+		var rawDocs []bson.Raw
+		cursor := lo.Must(m.aggregate(ctx, stages))
+		m.checkConditionsAndPanicForErr(cursor.All(ctx, &rawDocs))
+		hasMore := int64(len(rawDocs)) > limit
+		if hasMore {
+			rawDocs = rawDocs[:limit]
+		}
+		if reversed {
+			slices.Reverse(rawDocs)
+		}
+		docs := lo.Map(rawDocs, func(raw bson.Raw, _ int) T {
+			var doc T
+			lo.Must0(bson.Unmarshal(raw, &doc))
+			return doc
+		})
+		m.checkConditionsAndPanic(docs)
+		result := CursorPaginateResult[T]{
+			Docs:    docs,
+			Limit:   limit,
+			HasPrev: current != nil,
+			HasNext: hasMore,
+		}
+		if reversed {
+			result.HasPrev, result.HasNext = hasMore, true
+		}
+		if len(rawDocs) > 0 {
+			if result.HasNext {
+				result.NextCursor = lo.ToPtr(encodePaginationCursor(cursorDirectionNext, sort, rawDocs[len(rawDocs)-1]))
+			}
+			if result.HasPrev {
+				result.PrevCursor = lo.ToPtr(encodePaginationCursor(cursorDirectionPrev, sort, rawDocs[0]))
+			}
+		}
+		if !paginateOpts.SkipCount {
+			var counts []map[string]any
+			cursor := lo.Must(m.aggregate(ctx, append(slices.Clone(pipeline), bson.D{{Key: "$count", Value: "count"}})))
+			m.checkConditionsAndPanicForErr(cursor.All(ctx, &counts))
+			result.TotalDocs = lo.ToPtr(cast.ToInt64(lo.FirstOrEmpty(counts)["count"]))
+		}
+		return result
+	}
+	return m
+}
+
+// Separates the sort stages from the rest of the pipeline and returns both.
+func (m Model[T]) splitSortStages() (mongo.Pipeline, bson.D) {
+	var pipeline mongo.Pipeline
+	var sort bson.D
+	for _, stage := range m.pipeline {
+		if stage[0].Key != "$sort" {
+			pipeline = append(pipeline, stage)
+			continue
+		}
+		for _, e := range utils.CastBSON[bson.D](stage[0].Value) {
+			if _, exists := lo.Find(sort, func(s bson.E) bool { return s.Key == e.Key }); !exists {
+				sort = append(sort, e)
+			}
+		}
+	}
+	return pipeline, sort
+}
+
+// Builds a filter matching all documents which come strictly after the given values in the given sort order.
+// Null and missing values sort before all others, but are never matched by a range comparison, hence they are compared separately.
+func keysetFilter(sort bson.D, values bson.A, reversed bool) primitive.M {
+	clauses := make([]primitive.M, 0, len(sort))
+	for i, e := range sort {
+		descending := (cast.ToInt(e.Value) < 0) != reversed
+		var clause primitive.M
+		switch {
+		case isNullCursorValue(values[i]) && descending:
+			continue // Nothing comes after null when moving towards lower values
+		case isNullCursorValue(values[i]):
+			clause = primitive.M{e.Key: primitive.M{"$ne": nil}}
+		case descending:
+			clause = primitive.M{"$or": bson.A{primitive.M{e.Key: primitive.M{"$lt": values[i]}}, primitive.M{e.Key: nil}}}
+		default:
+			clause = primitive.M{e.Key: primitive.M{"$gt": values[i]}}
+		}
+		for j := range i {
+			clause[sort[j].Key] = values[j]
+		}
+		clauses = append(clauses, clause)
+	}
+	switch len(clauses) {
+	case 0:
+		return primitive.M{"_id": primitive.M{"$exists": false}} // Matches nothing
+	case 1:
+		return clauses[0]
+	}
+	return primitive.M{"$or": clauses}
+}
+
+// Reports whether a value held by a cursor stands for a null or missing field.
+func isNullCursorValue(value any) bool {
+	switch value.(type) {
+	case nil, primitive.Null, primitive.Undefined:
+		return true
+	}
+	return false
+}
+
+func encodePaginationCursor(direction cursorDirection, sort bson.D, doc bson.Raw) string {
+	values := lo.Map(sort, func(e bson.E, _ int) any {
+		value, err := doc.LookupErr(strings.Split(e.Key, ".")...)
+		if err != nil {
+			return nil
+		}
+		return value
+	})
+	payload := lo.Must(bson.MarshalExtJSON(bson.D{
+		{Key: "d", Value: direction},
+		{Key: "v", Value: values},
+	}, true, false))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+func decodePaginationCursor(token string) paginationCursor {
+	var cursor paginationCursor
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		panic(ErrInvalidCursor)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		panic(ErrInvalidCursor)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signCursor(payload)) {
+		panic(ErrInvalidCursor)
+	}
+	if bson.UnmarshalExtJSON(payload, true, &cursor) != nil {
+		panic(ErrInvalidCursor)
+	}
+	if cursor.Direction != cursorDirectionNext && cursor.Direction != cursorDirectionPrev {
+		panic(ErrInvalidCursor)
+	}
+	return cursor
+}
+
+func signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -3,6 +3,7 @@ package elemental
 import (
 	"context"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/elcengine/elemental/utils"
@@ -10,81 +11,401 @@ import (
 	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// Implemented by every model so that the references of a model can be populated without knowing its type.
+type populator interface {
+	collectionName() string
+	populateStages(values ...any) mongo.Pipeline
+	scopeFilter(ctx context.Context) primitive.M
+}
+
+func (m Model[T]) collectionName() string {
+	return m.Schema.Options.Collection
+}
+
+// Returns only the stages required to populate the given values on documents of this model.
+func (m Model[T]) populateStages(values ...any) mongo.Pipeline {
+	m.pipeline = nil
+	return m.Populate(values...).pipeline
+}
+
 func (m Model[T]) populate(value any) Model[T] {
-	var path, fieldname string
-	var selectField any
-	var subpipeline any
+	var path string
+	var opts primitive.M
 	switch v := value.(type) {
 	case primitive.M:
 		path = utils.Cast[string](v["path"])
-		selectField = v["select"]
-		subpipeline = v["pipeline"]
+		opts = v
 	default:
 		path = utils.Cast[string](value)
 	}
-	if path != "" {
-		for i := range m.docReflectType.NumField() {
-			field := m.docReflectType.Field(i)
-			tag := cleanTag(field.Tag.Get("bson"))
-			if path == field.Name {
-				path = tag
+	if path == "" {
+		return m
+	}
+	segments := strings.Split(path, ".")
+	if virtual, ok := m.Schema.Options.Virtuals[segments[0]]; ok {
+		return m.addVirtualPopulateLookup(segments[0], virtual, opts, strings.Join(segments[1:], "."))
+	}
+	reflectedType := m.docReflectType
+	schema := m.Schema
+	resolved := make([]string, 0, len(segments)) // The bson names of the segments resolved so far
+	arrayIndex := -1                             // The index of the segment which holds an array of subdocuments if any
+	for i, segment := range segments {
+		name, definition, fieldType := resolvePopulateField(reflectedType, schema, segment)
+		if definition == nil {
+			return m
+		}
+		resolved = append(resolved, name)
+		if definition.Collection != "" || definition.Ref != "" {
+			return m.addPopulateLookup(resolved, arrayIndex, *definition, opts, strings.Join(segments[i+1:], "."))
+		}
+		if definition.Schema == nil {
+			return m
+		}
+		for fieldType != nil && fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if isSliceField(*definition) {
+			if arrayIndex != -1 {
+				return m // Arrays nested within arrays of subdocuments are not supported
 			}
-			if path == tag {
-				fieldname = field.Name
-				break
+			arrayIndex = i
+			if fieldType != nil && (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) {
+				fieldType = fieldType.Elem()
 			}
 		}
-		if fieldname != "" {
-			schemaField := m.Schema.Field(fieldname)
-			if schemaField != nil {
-				collection := schemaField.Collection
-				if collection == "" {
-					if schemaField.Ref != "" {
-						model := reflect.ValueOf(Models[schemaField.Ref])
-						collection = model.FieldByName("Schema").FieldByName("Options").FieldByName("Collection").String()
-					}
-				}
-				if collection != "" {
-					lookup := primitive.M{
-						"from":         collection,
-						"localField":   path,
-						"foreignField": "_id",
-						"as":           path,
-					}
-					if subpipeline != nil {
-						lookup["pipeline"] = subpipeline
-					} else if selectField != nil {
-						lookup["pipeline"] = []primitive.M{
-							{"$project": selectField},
-						}
-					}
-					m.pipeline = append(m.pipeline, bson.D{{Key: "$lookup", Value: lookup}})
-					if schemaField.Type != reflect.Slice {
-						unwind := primitive.M{
-							"path":                       "$" + path,
-							"preserveNullAndEmptyArrays": true,
-						}
-						m.pipeline = append(m.pipeline, bson.D{{Key: "$unwind", Value: unwind}})
-					} else {
-						m.pipeline = append(m.pipeline, bson.D{{Key: "$lookup", Value: lookup}})
-					}
+		reflectedType, schema = fieldType, *definition.Schema
+	}
+	return m
+}
+
+// Adds the stages required to populate the reference at the given resolved path.
+// If the path continues beyond the reference, the rest of it is populated recursively within the referenced model.
+func (m Model[T]) addPopulateLookup(resolved []string, arrayIndex int, definition Field, opts primitive.M, nested string) Model[T] {
+	collection := definition.Collection
+	ref, _ := Models[definition.Ref].(populator)
+	if collection == "" && ref != nil {
+		collection = ref.collectionName()
+	}
+	if collection == "" {
+		return m
+	}
+	var pipeline bson.A
+	if nested != "" {
+		if ref == nil {
+			return m // The rest of the path cannot be resolved without a referenced model
+		}
+		var value any = nested
+		if opts != nil {
+			value = lo.Assign(opts, primitive.M{"path": nested})
+		}
+		pipeline = appendStages(pipeline, ref.populateStages(value))
+	} else {
+		pipeline = populateSubPipeline(opts, ref)
+	}
+	pipeline = withPopulateScopes(pipeline, ref)
+	path := strings.Join(resolved, ".")
+	as := path
+	if arrayIndex != -1 {
+		as = "__populated_" + strings.ReplaceAll(path, ".", "_")
+	}
+	if extended, ok := m.extendPopulateLookup(as, pipeline); ok {
+		return extended
+	}
+	lookup := primitive.M{
+		"from":         collection,
+		"localField":   path,
+		"foreignField": "_id",
+		"as":           as,
+	}
+	if len(pipeline) > 0 {
+		lookup["pipeline"] = pipeline
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$lookup", Value: lookup}})
+	if arrayIndex == -1 {
+		if !isSliceField(definition) {
+			m.pipeline = append(m.pipeline, bson.D{{Key: "$unwind", Value: primitive.M{
+				"path":                       "$" + path,
+				"preserveNullAndEmptyArrays": true,
+			}}})
+		}
+		return m
+	}
+	// Each element of the array is merged with the documents it references from the temporary lookup field
+	arrayPath := strings.Join(resolved[:arrayIndex+1], ".")
+	inner := resolved[arrayIndex+1:]
+	localField := "$$elem." + strings.Join(inner, ".")
+	var populated any
+	if isSliceField(definition) {
+		populated = primitive.M{"$filter": primitive.M{
+			"input": "$" + as,
+			"cond":  primitive.M{"$in": bson.A{"$$this._id", primitive.M{"$ifNull": bson.A{localField, bson.A{}}}}},
+		}}
+	} else {
+		populated = primitive.M{"$ifNull": bson.A{
+			primitive.M{"$arrayElemAt": bson.A{primitive.M{"$filter": primitive.M{
+				"input": "$" + as,
+				"cond":  primitive.M{"$eq": bson.A{"$$this._id", localField}},
+			}}, 0}},
+			nil,
+		}}
+	}
+	m.pipeline = append(m.pipeline,
+		bson.D{{Key: "$addFields", Value: primitive.M{arrayPath: primitive.M{"$map": primitive.M{
+			"input": primitive.M{"$ifNull": bson.A{"$" + arrayPath, bson.A{}}},
+			"as":    "elem",
+			"in":    mergeIntoPath("$$elem", inner, populated),
+		}}}}},
+		bson.D{{Key: "$unset", Value: as}},
+	)
+	return m
+}
+
+// Adds the stages required to populate the given virtual relation.
+// If the path continues beyond the virtual, the rest of it is populated recursively within the related model.
+func (m Model[T]) addVirtualPopulateLookup(name string, virtual Virtual, opts primitive.M, nested string) Model[T] {
+	collection := virtual.Collection
+	ref, _ := Models[virtual.Ref].(populator)
+	if collection == "" && ref != nil {
+		collection = ref.collectionName()
+	}
+	if collection == "" || virtual.ForeignField == "" {
+		return m
+	}
+	var pipeline bson.A
+	if nested != "" && ref != nil && !virtual.Count {
+		var value any = nested
+		if opts != nil {
+			value = lo.Assign(opts, primitive.M{"path": nested})
+		}
+		pipeline = appendStages(pipeline, ref.populateStages(value))
+	} else {
+		pipeline = populateSubPipeline(opts, ref)
+	}
+	pipeline = withPopulateScopes(pipeline, ref)
+	if extended, ok := m.extendPopulateLookup(name, pipeline); ok {
+		return extended
+	}
+	lookup := primitive.M{
+		"from":         collection,
+		"localField":   lo.CoalesceOrEmpty(virtual.LocalField, "_id"),
+		"foreignField": virtual.ForeignField,
+		"as":           name,
+	}
+	if len(pipeline) > 0 {
+		lookup["pipeline"] = pipeline
+	}
+	m.pipeline = append(m.pipeline, bson.D{{Key: "$lookup", Value: lookup}})
+	switch {
+	case virtual.Count:
+		m.pipeline = append(m.pipeline, bson.D{{Key: "$addFields", Value: primitive.M{
+			name: primitive.M{"$size": "$" + name},
+		}}})
+	case virtual.JustOne:
+		m.pipeline = append(m.pipeline, bson.D{{Key: "$addFields", Value: primitive.M{
+			name: primitive.M{"$arrayElemAt": bson.A{"$" + name, 0}},
+		}}})
+	}
+	return m
+}
+
+// Populating the same path more than once extends the existing lookup instead of adding a conflicting one.
+// Returns false if there is no existing lookup for the given path.
+func (m Model[T]) extendPopulateLookup(as string, pipeline bson.A) (Model[T], bool) {
+	for i, stage := range m.pipeline {
+		if lookup, ok := stage[0].Value.(primitive.M); ok && stage[0].Key == "$lookup" && lookup["as"] == as {
+			lookup = lo.Assign(lookup)
+			lookup["pipeline"] = appendStages(appendStages(nil, lookup["pipeline"]), pipeline)
+			m.pipeline = slices.Clone(m.pipeline)
+			m.pipeline[i] = bson.D{{Key: "$lookup", Value: lookup}}
+			return m, true
+		}
+	}
+	return m, false
+}
+
+// A placeholder for the filters of the global scopes of a referenced model within a lookup pipeline.
+// It is resolved when the query runs, since the filters depend on the tenant of the query.
+type populateScope struct {
+	ref populator
+}
+
+// Returns the filters of the global scopes of this model which apply within the tenant of the given context.
+func (m Model[T]) scopeFilter(ctx context.Context) primitive.M {
+	return m.withTenant(ctx).globalScopeFilter()
+}
+
+// Prefixes the pipeline run on the referenced documents with the filters of the global scopes of the referenced model,
+// so that documents which are hidden from the referenced model, such as soft deleted ones, are not populated either.
+func withPopulateScopes(pipeline bson.A, ref populator) bson.A {
+	if ref == nil {
+		return pipeline
+	}
+	return append(bson.A{primitive.M{"$match": populateScope{ref: ref}}}, pipeline...)
+}
+
+// Replaces the placeholders for the global scopes of referenced models within the lookups of the given pipeline
+// with their filters within the tenant of the given context. Placeholders of models without any scope which applies are removed.
+// The given pipeline is not modified.
+func resolvePopulateScopes(ctx context.Context, pipeline mongo.Pipeline) mongo.Pipeline {
+	return replacePopulateScopes(pipeline, func(ref populator) primitive.M {
+		return ref.scopeFilter(ctx)
+	})
+}
+
+// Removes the placeholders for the global scopes of referenced models within the lookups of the given pipeline,
+// for when the pipeline is only rendered and there is no tenant to resolve them within. The given pipeline is not modified.
+func stripPopulateScopes(pipeline mongo.Pipeline) mongo.Pipeline {
+	return replacePopulateScopes(pipeline, func(populator) primitive.M {
+		return nil
+	})
+}
+
+// Replaces the placeholders for the global scopes of referenced models within the lookups of the given pipeline
+// with the filters returned by the given function, removing those for which it returns an empty filter.
+func replacePopulateScopes(pipeline mongo.Pipeline, filter func(ref populator) primitive.M) mongo.Pipeline {
+	return lo.Map(pipeline, func(stage bson.D, _ int) bson.D {
+		if len(stage) == 0 || stage[0].Key != "$lookup" {
+			return stage
+		}
+		return bson.D{{Key: "$lookup", Value: replaceLookupScopes(stage[0].Value, filter)}}
+	})
+}
+
+// Replaces the placeholders for the global scopes of referenced models within the pipeline of the given lookup and any lookups nested in it.
+func replaceLookupScopes(lookup any, filter func(ref populator) primitive.M) any {
+	spec, ok := lookup.(primitive.M)
+	if !ok {
+		return lookup
+	}
+	stages, ok := spec["pipeline"].(bson.A)
+	if !ok {
+		return lookup
+	}
+	var pipeline bson.A
+	for _, stage := range stages {
+		switch s := stage.(type) {
+		case primitive.M:
+			if scope, ok := s["$match"].(populateScope); ok {
+				if match := filter(scope.ref); len(match) > 0 {
+					pipeline = append(pipeline, primitive.M{"$match": match})
 				}
+				continue
+			}
+			if nested, ok := s["$lookup"]; ok {
+				stage = primitive.M{"$lookup": replaceLookupScopes(nested, filter)}
+			}
+		case bson.D:
+			if len(s) > 0 && s[0].Key == "$lookup" {
+				stage = bson.D{{Key: "$lookup", Value: replaceLookupScopes(s[0].Value, filter)}}
 			}
 		}
+		pipeline = append(pipeline, stage)
 	}
-	return m
+	spec = lo.Assign(spec)
+	if len(pipeline) == 0 {
+		delete(spec, "pipeline")
+	} else {
+		spec["pipeline"] = pipeline
+	}
+	return spec
+}
+
+// Builds the pipeline to run on the referenced documents from the options of a populate call.
+func populateSubPipeline(opts primitive.M, ref populator) bson.A {
+	var pipeline bson.A
+	if opts == nil {
+		return pipeline
+	}
+	if opts["match"] != nil {
+		pipeline = append(pipeline, primitive.M{"$match": opts["match"]})
+	}
+	if opts["sort"] != nil {
+		pipeline = append(pipeline, primitive.M{"$sort": opts["sort"]})
+	}
+	if opts["limit"] != nil {
+		pipeline = append(pipeline, primitive.M{"$limit": opts["limit"]})
+	}
+	if opts["select"] != nil {
+		pipeline = append(pipeline, primitive.M{"$project": opts["select"]})
+	}
+	if opts["pipeline"] != nil {
+		pipeline = appendStages(pipeline, opts["pipeline"])
+	}
+	if opts["populate"] != nil && ref != nil {
+		var values []any
+		switch v := opts["populate"].(type) {
+		case []any:
+			values = v
+		case []primitive.M:
+			values = lo.ToAnySlice(v)
+		default:
+			values = []any{v}
+		}
+		pipeline = appendStages(pipeline, ref.populateStages(values...))
+	}
+	return pipeline
+}
+
+// Retrieves the bson name, schema definition and reflected type of the field matching the given path segment.
+// The segment can either be the name of the field in the struct or its bson name.
+func resolvePopulateField(reflectedType reflect.Type, schema Schema, segment string) (string, *Field, reflect.Type) {
+	if reflectedType != nil && reflectedType.Kind() == reflect.Struct {
+		for i := range reflectedType.NumField() {
+			field := reflectedType.Field(i)
+			tag := cleanTag(field.Tag.Get("bson"))
+			if segment == field.Name || segment == tag {
+				return tag, schema.Field(field.Name), field.Type
+			}
+		}
+	}
+	return segment, schema.Field(segment), nil
+}
+
+func isSliceField(definition Field) bool {
+	if t, ok := definition.Type.(reflect.Type); ok {
+		return t.Kind() == reflect.Slice // An ObjectID is an array by kind, hence arrays are not considered
+	}
+	return definition.Type == reflect.Slice
+}
+
+// Appends the stages of any kind of pipeline such as a mongo.Pipeline or a slice of maps to the given pipeline.
+func appendStages(pipeline bson.A, stages any) bson.A {
+	value := reflect.ValueOf(stages)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return pipeline
+	}
+	for i := range value.Len() {
+		pipeline = append(pipeline, value.Index(i).Interface())
+	}
+	return pipeline
+}
+
+// Builds an expression which merges the given value into the given variable at the given path.
+func mergeIntoPath(variable string, segments []string, value any) primitive.M {
+	if len(segments) > 1 {
+		value = mergeIntoPath(variable+"."+segments[0], segments[1:], value)
+	}
+	return primitive.M{"$mergeObjects": bson.A{variable, primitive.M{segments[0]: value}}}
 }
 
 // Finds and attaches the referenced documents to the main document returned by the query.
 // The fields to populate must have a 'Collection' or 'Ref' property in their schema definition.
 //
-// It can accept a single string, a slice of strings, or a map with 'path' and optionally a 'select' or a 'pipeline' key.
+// It can accept a single string, a slice of strings, or a map with 'path' and optionally any of the following keys:
+//   - 'match', 'sort', 'limit' and 'select' to filter, order, cap and project the referenced documents
+//   - 'pipeline' to run a custom pipeline on the referenced documents
+//   - 'populate' to populate the references of the referenced documents in turn. Requires the field to have a 'Ref' property
+//
+// Paths can be dotted to populate references within subdocuments, within a single level of arrays of subdocuments,
+// or within referenced documents themselves. E.g. "author.company" or "chapters.reviewer".
+// Virtual relations declared in the schema options can be populated by their name just like any other field.
 func (m Model[T]) Populate(values ...any) Model[T] {
 	m.setResult([]bson.M{})
 	m.executor = func(m Model[T], ctx context.Context) any {
-		cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+		cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 		lo.Must0(cursor.All(ctx, m.result))
 		m.checkConditionsAndPanic(m.result)
 		return m.result
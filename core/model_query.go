@@ -50,7 +50,7 @@ func (m Model[T]) Exec(ctx ...context.Context) any {
 	if m.executor == nil {
 		m.executor = func(m Model[T], ctx context.Context) any {
 			var results []T
-			cursor := lo.Must(m.Collection().Aggregate(ctx, m.pipeline))
+			cursor := lo.Must(m.aggregate(ctx, m.pipeline))
 			lo.Must0(cursor.All(ctx, &results))
 			m.checkConditionsAndPanic(results)
 			return results
@@ -59,7 +59,7 @@ func (m Model[T]) Exec(ctx ...context.Context) any {
 	if m.schedule != nil {
 		id, err := cron.AddFunc(*m.schedule, func() {
 			lo.TryCatchWithErrorValue(func() error {
-				m.executor(m, utils.CtxOrDefault(ctx))
+				m.execute(utils.CtxOrDefault(ctx))
 				return nil
 			}, func(err any) {
 				if m.onScheduleExecError != nil {
@@ -73,7 +73,7 @@ func (m Model[T]) Exec(ctx ...context.Context) any {
 		cron.Start()
 		return cast.ToInt(id)
 	}
-	return m.executor(m, utils.CtxOrDefault(ctx))
+	return m.execute(utils.CtxOrDefault(ctx))
 }
 
 // ExecT is a convenience method that executes the query and returns the first result.
@@ -2,6 +2,7 @@ package elemental
 
 import (
 	"context"
+	"maps"
 	"reflect"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/samber/lo"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Extends the query with a delete operation matching the given query(s)
@@ -16,15 +18,24 @@ import (
 // It deletes only the first document that matches the query.
 // This method will return the deleted document.
 // If the model has soft delete enabled, it will update the document with a deleted_at field instead of deleting it.
+// The on delete actions of the fields of other models which reference the document are enforced beforehand.
 func (m Model[T]) FindOneAndDelete(query ...primitive.M) Model[T] {
-	q := utils.MergedQueryOrDefault(query)
+	return m.withReferentialActions(utils.MergedQueryOrDefault(query), Model[T].findOneAndDelete, true)
+}
+
+// Builds the delete of FindOneAndDelete without enforcing any on delete actions.
+func (m Model[T]) findOneAndDelete(q primitive.M) Model[T] {
 	if m.softDeleteEnabled {
 		m = m.FindOneAndUpdate(&q, m.softDeletePayload())
 	} else {
 		m.executor = func(m Model[T], ctx context.Context) any {
+			defer m.invalidateCache()
 			var doc T
-			m.middleware.pre.findOneAndDelete.run(&q)
-			result := m.Collection().FindOneAndDelete(ctx, q)
+			filters := maps.Clone(q)
+			maps.Copy(filters, m.findMatchStage())
+			filters = m.withGlobalScopeFilters(filters)
+			m.middleware.pre.findOneAndDelete.run(&filters)
+			result := m.Collection().FindOneAndDelete(ctx, filters, parseUpdateOptions(m, []*options.FindOneAndDeleteOptions{})...)
 			m.checkConditionsAndPanic(result)
 			lo.Must0(result.Decode(&doc))
 			m.middleware.post.findOneAndDelete.run(&doc)
@@ -38,14 +49,10 @@ func (m Model[T]) FindOneAndDelete(query ...primitive.M) Model[T] {
 // It deletes only the first document that matches the id.
 // This method will return the deleted document.
 // If the model has soft delete enabled, it will update the document with a deleted_at field instead of deleting it.
+// The on delete actions of the fields of other models which reference the document are enforced beforehand.
 // The id can be a string or an ObjectID.
 func (m Model[T]) FindByIDAndDelete(id any) Model[T] {
-	if m.softDeleteEnabled {
-		return m.FindOneAndUpdate(lo.ToPtr(primitive.M{"_id": utils.EnsureObjectID(id)}),
-			m.softDeletePayload())
-	} else {
-		return m.FindOneAndDelete(primitive.M{"_id": utils.EnsureObjectID(id)})
-	}
+	return m.FindOneAndDelete(primitive.M{"_id": utils.EnsureObjectID(id)})
 }
 
 // Extends the query with a delete operation matching the given query(s).
@@ -53,14 +60,23 @@ func (m Model[T]) FindByIDAndDelete(id any) Model[T] {
 // It deletes only the first document that matches the query.
 // This method will not return the deleted document.
 // If the model has soft delete enabled, it will update the document with a deleted_at field instead of deleting it.
+// The on delete actions of the fields of other models which reference the document are enforced beforehand.
 func (m Model[T]) DeleteOne(query ...primitive.M) Model[T] {
-	q := utils.MergedQueryOrDefault(query)
+	return m.withReferentialActions(utils.MergedQueryOrDefault(query), Model[T].deleteOne, true)
+}
+
+// Builds the delete of DeleteOne without enforcing any on delete actions.
+func (m Model[T]) deleteOne(q primitive.M) Model[T] {
 	if m.softDeleteEnabled {
 		m = m.UpdateOne(&q, m.softDeletePayload())
 	} else {
 		m.executor = func(m Model[T], ctx context.Context) any {
-			m.middleware.pre.deleteOne.run(&q)
-			result, err := m.Collection().DeleteOne(ctx, q)
+			defer m.invalidateCache()
+			filters := maps.Clone(q)
+			maps.Copy(filters, m.findMatchStage())
+			filters = m.withGlobalScopeFilters(filters)
+			m.middleware.pre.deleteOne.run(&filters)
+			result, err := m.Collection().DeleteOne(ctx, filters, parseUpdateOptions(m, []*options.DeleteOptions{})...)
 			m.checkConditionsAndPanicForErr(err)
 			m.middleware.post.deleteOne.run(result, err)
 			return result
@@ -73,28 +89,19 @@ func (m Model[T]) DeleteOne(query ...primitive.M) Model[T] {
 // It deletes only the first document that matches the id.
 // This method will not return the deleted document.
 // If the model has soft delete enabled, it will update the document with a deleted_at field instead of deleting it.
+// The on delete actions of the fields of other models which reference the document are enforced beforehand.
 // The id can be a string or an ObjectID.
 func (m Model[T]) DeleteByID(id any) Model[T] {
-	if m.softDeleteEnabled {
-		return m.UpdateOne(lo.ToPtr(primitive.M{"_id": utils.EnsureObjectID(id)}), m.softDeletePayload())
-	} else {
-		return m.DeleteOne(primitive.M{"_id": utils.EnsureObjectID(id)})
-	}
+	return m.DeleteOne(primitive.M{"_id": utils.EnsureObjectID(id)})
 }
 
 // Extends the query with a delete operation matching the given document.
 // If the model has soft delete enabled, it will update the document with a deleted_at field instead of deleting it.
+// The on delete actions of the fields of other models which reference the document are enforced beforehand.
 func (m Model[T]) Delete(doc T) Model[T] {
-	if m.softDeleteEnabled {
-		m.executor = func(m Model[T], ctx context.Context) any {
-			m.UpdateByID(reflect.ValueOf(doc).FieldByName("ID").Interface().(primitive.ObjectID), m.softDeletePayload()).Exec(ctx) //nolint:contextcheck
-			return nil
-		}
-	} else {
-		m.executor = func(m Model[T], ctx context.Context) any {
-			m.DeleteByID(reflect.ValueOf(doc).FieldByName("ID").Interface().(primitive.ObjectID)).Exec(ctx) //nolint:contextcheck
-			return nil
-		}
+	m.executor = func(m Model[T], ctx context.Context) any {
+		m.DeleteByID(reflect.ValueOf(doc).FieldByName("ID").Interface().(primitive.ObjectID)).Exec(ctx) //nolint:contextcheck
+		return nil
 	}
 	return m
 }
@@ -104,14 +111,23 @@ func (m Model[T]) Delete(doc T) Model[T] {
 // It deletes all documents that match the query.
 // This method will not return the deleted documents.
 // If the model has soft delete enabled, it will update the documents with a deleted_at field instead of deleting them.
+// The on delete actions of the fields of other models which reference the documents are enforced beforehand.
 func (m Model[T]) DeleteMany(query ...primitive.M) Model[T] {
-	q := utils.MergedQueryOrDefault(query)
+	return m.withReferentialActions(utils.MergedQueryOrDefault(query), Model[T].deleteMany, false)
+}
+
+// Builds the delete of DeleteMany without enforcing any on delete actions.
+func (m Model[T]) deleteMany(q primitive.M) Model[T] {
 	if m.softDeleteEnabled {
 		m = m.UpdateMany(&q, m.softDeletePayload())
 	} else {
 		m.executor = func(m Model[T], ctx context.Context) any {
-			m.middleware.pre.deleteMany.run(&q)
-			result, err := m.Collection().DeleteMany(ctx, q)
+			defer m.invalidateCache()
+			filters := maps.Clone(q)
+			maps.Copy(filters, m.findMatchStage())
+			filters = m.withGlobalScopeFilters(filters)
+			m.middleware.pre.deleteMany.run(&filters)
+			result, err := m.Collection().DeleteMany(ctx, filters, parseUpdateOptions(m, []*options.DeleteOptions{})...)
 			m.checkConditionsAndPanicForErr(err)
 			m.middleware.post.deleteMany.run(result, err)
 			return result
@@ -134,3 +150,61 @@ func (m *Model[T]) DisableSoftDelete() {
 func (m Model[T]) softDeletePayload() primitive.M {
 	return primitive.M{m.deletedAtFieldName: time.Now().Format(time.RFC3339)}
 }
+
+// Extends the query to include soft deleted documents along with all other documents.
+// This is the same as excluding the SoftDeleteScope.
+func (m Model[T]) WithTrashed() Model[T] {
+	return m.WithoutScope(SoftDeleteScope)
+}
+
+// Extends the query to match only soft deleted documents.
+func (m Model[T]) OnlyTrashed() Model[T] {
+	whereField := m.whereField
+	m = m.WithTrashed().Where(m.deletedAtField()).Exists(true)
+	m.whereField = whereField
+	return m
+}
+
+// Extends the query with an update operation which restores all soft deleted documents matching the given query(s).
+// If multiple queries are provided, they are merged into a single from left to right.
+// This method will return the result of the update.
+func (m Model[T]) Restore(query ...primitive.M) Model[T] {
+	q := utils.MergedQueryOrDefault(query)
+	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
+		filters := maps.Clone(m.OnlyTrashed().findMatchStage())
+		maps.Copy(filters, q)
+		filters = m.WithTrashed().withGlobalScopeFilters(filters)
+		result, err := m.Collection().UpdateMany(ctx, filters, primitive.M{"$unset": primitive.M{m.deletedAtField(): ""}},
+			parseUpdateOptions(m, []*options.UpdateOptions{})...)
+		m.checkConditionsAndPanicForErr(err)
+		return result
+	}
+	return m
+}
+
+// Extends the query with an update operation which restores the soft deleted document with the given id.
+// The id can be a string or an ObjectID.
+func (m Model[T]) RestoreByID(id any) Model[T] {
+	return m.Restore(primitive.M{"_id": utils.EnsureObjectID(id)})
+}
+
+// Extends the query with a delete operation which permanently deletes all documents matching the given query(s),
+// regardless of whether soft delete is enabled and including documents which have already been soft deleted.
+// If multiple queries are provided, they are merged into a single from left to right.
+func (m Model[T]) ForceDelete(query ...primitive.M) Model[T] {
+	m.softDeleteEnabled = false
+	return m.DeleteMany(query...)
+}
+
+// Extends the query with a delete operation which permanently deletes the document with the given id,
+// regardless of whether soft delete is enabled and even if it has already been soft deleted.
+// The id can be a string or an ObjectID.
+func (m Model[T]) ForceDeleteByID(id any) Model[T] {
+	m.softDeleteEnabled = false
+	return m.DeleteByID(id)
+}
+
+func (m Model[T]) deletedAtField() string {
+	return lo.CoalesceOrEmpty(m.deletedAtFieldName, "deleted_at")
+}
@@ -0,0 +1,94 @@
+package elemental
+
+import (
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Driver level options which apply to the execution of a query rather than to its pipeline.
+type queryOptions struct {
+	hint         any
+	maxTime      *time.Duration
+	allowDiskUse *bool
+	comment      *string
+	batchSize    *int32
+	arrayFilters []any
+}
+
+// Forces the query to use the given index. The index can be given either by its name or by its key specification.
+// Also applies to update, replace and delete operations.
+//
+// Usage:
+//
+//	UserModel.Find(primitive.M{"age": 30}).Hint("age_1")
+//	UserModel.Find(primitive.M{"age": 30}).Hint(bson.D{{Key: "age", Value: 1}})
+func (m Model[T]) Hint(index any) Model[T] {
+	m.queryOptions.hint = index
+	return m
+}
+
+// Sets the maximum amount of time the query is allowed to run on the server before it is aborted.
+// Also applies to find and modify operations such as FindOneAndUpdate.
+func (m Model[T]) MaxTime(duration time.Duration) Model[T] {
+	m.queryOptions.maxTime = &duration
+	return m
+}
+
+// Allows the query to write temporary data to disk when a stage exceeds the memory limit of the server, such as large sorts.
+// It optionally accepts a boolean to explicitly disable this behavior.
+func (m Model[T]) AllowDiskUse(allow ...bool) Model[T] {
+	m.queryOptions.allowDiskUse = lo.ToPtr(lo.FirstOr(allow, true))
+	return m
+}
+
+// Attaches the given comment to the query which then appears in the profiler, logs and currentOp output of the server.
+// Also applies to update, replace and delete operations.
+func (m Model[T]) Comment(comment string) Model[T] {
+	m.queryOptions.comment = &comment
+	return m
+}
+
+// Sets the number of documents to return in each batch of the response from the server.
+func (m Model[T]) BatchSize(size int32) Model[T] {
+	m.queryOptions.batchSize = &size
+	return m
+}
+
+// Sets the filters which determine the array elements an update applies to through filtered positional operators such as $[elem].
+// Each filter is a document whose keys are prefixed with the identifier of a positional operator used in the update.
+// Applies to update and find and modify operations, accumulating across calls.
+//
+// Usage:
+//
+//	UserModel.Where("name", "Geralt").
+//		Set(primitive.M{"contracts.$[contract].completed": true}).
+//		ArrayFilters(primitive.M{"contract.monster": "Griffin"}).
+//		Exec()
+func (m Model[T]) ArrayFilters(filters ...any) Model[T] {
+	m.queryOptions.arrayFilters = append(slices.Clone(m.queryOptions.arrayFilters), filters...)
+	return m
+}
+
+// Builds the driver options for an aggregate command from the query options set on this model.
+func (m Model[T]) aggregateOptions() *options.AggregateOptions {
+	opts := options.Aggregate()
+	if m.queryOptions.hint != nil {
+		opts.SetHint(m.queryOptions.hint)
+	}
+	if m.queryOptions.maxTime != nil {
+		opts.SetMaxTime(*m.queryOptions.maxTime)
+	}
+	if m.queryOptions.allowDiskUse != nil {
+		opts.SetAllowDiskUse(*m.queryOptions.allowDiskUse)
+	}
+	if m.queryOptions.comment != nil {
+		opts.SetComment(*m.queryOptions.comment)
+	}
+	if m.queryOptions.batchSize != nil {
+		opts.SetBatchSize(*m.queryOptions.batchSize)
+	}
+	return opts
+}
@@ -2,12 +2,15 @@ package elemental
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"strings"
 
 	"github.com/elcengine/elemental/utils"
 	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -16,9 +19,11 @@ import (
 // It updates only the first document that matches the query.
 func (m Model[T]) FindOneAndUpdate(query *primitive.M, doc any, opts ...*options.FindOneAndUpdateOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		var resultDoc T
 		filters := lo.FromPtr(query)
 		maps.Copy(filters, m.findMatchStage())
+		filters = m.withGlobalScopeFilters(filters)
 		m.middleware.pre.findOneAndUpdate.run(&filters, &doc)
 		result := m.Collection().FindOneAndUpdate(ctx, filters,
 			primitive.M{"$set": m.parseDocument(doc)}, parseUpdateOptions(m, opts)...)
@@ -34,8 +39,9 @@ func (m Model[T]) FindOneAndUpdate(query *primitive.M, doc any, opts ...*options
 // The id can be a string or an ObjectID.
 func (m Model[T]) FindByIDAndUpdate(id any, doc any, opts ...*options.FindOneAndUpdateOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		var resultDoc T
-		result := m.Collection().FindOneAndUpdate(ctx, primitive.M{"_id": utils.EnsureObjectID(id)},
+		result := m.Collection().FindOneAndUpdate(ctx, m.withGlobalScopeFilters(primitive.M{"_id": utils.EnsureObjectID(id)}),
 			primitive.M{"$set": m.parseDocument(doc)}, parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanic(result)
 		lo.Must0(result.Decode(&resultDoc))
@@ -49,11 +55,13 @@ func (m Model[T]) FindByIDAndUpdate(id any, doc any, opts ...*options.FindOneAnd
 // It updates only the first document that matches the query.
 func (m Model[T]) UpdateOne(query *primitive.M, doc any, opts ...*options.UpdateOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		filters := make(primitive.M)
 		if query != nil {
 			filters = lo.FromPtr(query)
 		}
 		maps.Copy(filters, m.findMatchStage())
+		filters = m.withGlobalScopeFilters(filters)
 		m.middleware.pre.updateOne.run(&doc)
 		result, err := m.Collection().UpdateOne(ctx, filters,
 			primitive.M{"$set": m.parseDocument(doc)}, parseUpdateOptions(m, opts)...)
@@ -69,7 +77,8 @@ func (m Model[T]) UpdateOne(query *primitive.M, doc any, opts ...*options.Update
 // The id can be a string or an ObjectID.
 func (m Model[T]) UpdateByID(id any, doc any, opts ...*options.UpdateOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
-		result, err := m.Collection().UpdateOne(ctx, primitive.M{"_id": utils.EnsureObjectID(id)},
+		defer m.invalidateCache()
+		result, err := m.Collection().UpdateOne(ctx, m.withGlobalScopeFilters(primitive.M{"_id": utils.EnsureObjectID(id)}),
 			primitive.M{"$set": m.parseDocument(doc)}, parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanicForErr(err)
 		return result
@@ -80,10 +89,15 @@ func (m Model[T]) UpdateByID(id any, doc any, opts ...*options.UpdateOptions) Mo
 // Extends the query with an upsert operation matching the id of the given document
 func (m Model[T]) Save(doc T) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
-		parsedDoc := m.parseDocument(doc)
+		defer m.invalidateCache()
+		parsedDoc := m.stampTenant(m.parseDocument(doc))
 		var resultDoc bson.M
 		m.middleware.pre.save.run(&parsedDoc)
-		result := m.Collection().FindOneAndUpdate(ctx, &primitive.M{"_id": parsedDoc["_id"]},
+		filter := primitive.M{"_id": parsedDoc["_id"]}
+		if m.tenant != nil {
+			filter[m.tenantField()] = m.tenant
+		}
+		result := m.Collection().FindOneAndUpdate(ctx, filter,
 			primitive.M{"$set": parsedDoc}, options.FindOneAndUpdate().SetUpsert(true))
 		m.checkConditionsAndPanic(result)
 		lo.Must0(result.Decode(&resultDoc))
@@ -98,12 +112,16 @@ func (m Model[T]) Save(doc T) Model[T] {
 // It updates all documents that match the query.
 func (m Model[T]) UpdateMany(query *primitive.M, doc any, opts ...*options.UpdateOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		filters := make(primitive.M)
 		if query != nil {
 			filters = lo.FromPtr(query)
 		}
 		maps.Copy(filters, m.findMatchStage())
+		filters = m.withGlobalScopeFilters(filters)
+		m.middleware.pre.updateMany.run(&doc)
 		result, err := m.Collection().UpdateMany(ctx, filters, primitive.M{"$set": m.parseDocument(doc)}, parseUpdateOptions(m, opts)...)
+		m.middleware.post.updateMany.run(result, err)
 		m.checkConditionsAndPanicForErr(err)
 		return result
 	}
@@ -115,12 +133,14 @@ func (m Model[T]) UpdateMany(query *primitive.M, doc any, opts ...*options.Updat
 // It replaces only the first document that matches the query.
 func (m Model[T]) ReplaceOne(query *primitive.M, doc any, opts ...*options.ReplaceOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		filters := make(primitive.M)
 		if query != nil {
 			filters = lo.FromPtr(query)
 		}
 		maps.Copy(filters, m.findMatchStage())
-		result, err := m.Collection().ReplaceOne(ctx, filters, m.parseDocument(doc),
+		filters = m.withGlobalScopeFilters(filters)
+		result, err := m.Collection().ReplaceOne(ctx, filters, m.stampTenant(m.parseDocument(doc)),
 			parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanicForErr(err)
 		return result
@@ -132,8 +152,9 @@ func (m Model[T]) ReplaceOne(query *primitive.M, doc any, opts ...*options.Repla
 // The id can be a string or an ObjectID.
 func (m Model[T]) ReplaceByID(id any, doc any, opts ...*options.ReplaceOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
-		result, err := m.Collection().ReplaceOne(ctx, primitive.M{"_id": utils.EnsureObjectID(id)},
-			m.parseDocument(doc), parseUpdateOptions(m, opts)...)
+		defer m.invalidateCache()
+		result, err := m.Collection().ReplaceOne(ctx, m.withGlobalScopeFilters(primitive.M{"_id": utils.EnsureObjectID(id)}),
+			m.stampTenant(m.parseDocument(doc)), parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanicForErr(err)
 		return result
 	}
@@ -145,14 +166,16 @@ func (m Model[T]) ReplaceByID(id any, doc any, opts ...*options.ReplaceOptions)
 // It replaces only the first document that matches the query.
 func (m Model[T]) FindOneAndReplace(query *primitive.M, doc any, opts ...*options.FindOneAndReplaceOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		var resultDoc T
 		filters := make(primitive.M)
 		if query != nil {
 			filters = lo.FromPtr(query)
 		}
 		maps.Copy(filters, m.findMatchStage())
+		filters = m.withGlobalScopeFilters(filters)
 		m.middleware.pre.findOneAndReplace.run(&filters, &doc)
-		res := m.Collection().FindOneAndReplace(ctx, filters, m.parseDocument(doc), opts...)
+		res := m.Collection().FindOneAndReplace(ctx, filters, m.stampTenant(m.parseDocument(doc)), parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanic(res)
 		lo.Must0(res.Decode(&resultDoc))
 		m.middleware.post.findOneAndReplace.run(&resultDoc)
@@ -166,9 +189,10 @@ func (m Model[T]) FindOneAndReplace(query *primitive.M, doc any, opts ...*option
 // The id can be a string or an ObjectID.
 func (m Model[T]) FindByIDAndReplace(id any, doc any, opts ...*options.FindOneAndReplaceOptions) Model[T] {
 	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
 		var resultDoc T
-		res := m.Collection().FindOneAndReplace(ctx, primitive.M{"_id": utils.EnsureObjectID(id)},
-			m.parseDocument(doc), parseUpdateOptions(m, opts)...)
+		res := m.Collection().FindOneAndReplace(ctx, m.withGlobalScopeFilters(primitive.M{"_id": utils.EnsureObjectID(id)}),
+			m.stampTenant(m.parseDocument(doc)), parseUpdateOptions(m, opts)...)
 		m.checkConditionsAndPanic(res)
 		lo.Must0(res.Decode(&resultDoc))
 		return resultDoc
@@ -176,10 +200,23 @@ func (m Model[T]) FindByIDAndReplace(id any, doc any, opts ...*options.FindOneAn
 	return m
 }
 
+// Extends the query to set the values of the given fields.
+// Update operators can be chained, in which case they are all executed as a single update against all documents matching the query.
+// Use One or FindOneAndModify to update only the first matching document instead.
+//
+// Usage:
+//
+//	UserModel.Where("name", "Geralt").Set(primitive.M{"occupation": "Vintner"}).Inc("age", 1).Push("weapons", "Aerondight").Exec()
 func (m Model[T]) Set(doc any) Model[T] {
 	return m.setUpdateOperator("$set", doc)
 }
 
+// Extends the query to set the values of the given fields only if the update results in a new document being inserted.
+// Has no effect unless the query is an upsert.
+func (m Model[T]) SetOnInsert(doc any) Model[T] {
+	return m.setUpdateOperator("$setOnInsert", doc)
+}
+
 func (m Model[T]) Unset(doc any) Model[T] {
 	if s, ok := doc.(string); ok {
 		doc = primitive.M{s: ""}
@@ -187,24 +224,25 @@ func (m Model[T]) Unset(doc any) Model[T] {
 	return m.setUpdateOperator("$unset", doc)
 }
 
-// Extends the query with an increment operation matching the given field
-func (m Model[T]) Inc(field string, value int) Model[T] {
-	return m.setUpdateOperator("$inc", primitive.M{field: value})
+// Extends the query with an increment operation matching the given field. The value can be of any numeric type.
+func (m Model[T]) Inc(field string, value any) Model[T] {
+	return m.setUpdateOperator("$inc", primitive.M{field: ensureNumeric(value)})
 }
 
-// Extends the query with a decrement operation matching the given field
-func (m Model[T]) Dec(field string, value int) Model[T] {
-	return m.setUpdateOperator("$inc", primitive.M{field: -value})
+// Extends the query with a decrement operation matching the given field. The value can be of any numeric type.
+func (m Model[T]) Dec(field string, value any) Model[T] {
+	return m.setUpdateOperator("$inc", primitive.M{field: negate(value)})
 }
 
-// Extends the query with a multiplication operation matching the given field
-func (m Model[T]) Mul(field string, value int) Model[T] {
-	return m.setUpdateOperator("$mul", primitive.M{field: value})
+// Extends the query with a multiplication operation matching the given field. The value can be of any numeric type.
+func (m Model[T]) Mul(field string, value any) Model[T] {
+	return m.setUpdateOperator("$mul", primitive.M{field: ensureNumeric(value)})
 }
 
-// Extends the query with a division operation matching the given field
-func (m Model[T]) Div(field string, value int) Model[T] {
-	return m.setUpdateOperator("$mul", primitive.M{field: (float64(1) / float64(value))})
+// Extends the query with a division operation matching the given field. The value can be of any numeric type.
+// Panics with ErrDivisionByZero if the value is zero.
+func (m Model[T]) Div(field string, value any) Model[T] {
+	return m.setUpdateOperator("$mul", primitive.M{field: reciprocal(value)})
 }
 
 // Extends the query to update the name of the given field
@@ -212,13 +250,15 @@ func (m Model[T]) Rename(field string, newField string) Model[T] {
 	return m.setUpdateOperator("$rename", primitive.M{field: newField})
 }
 
-// Extends the query to update the value of the given field if the given value is less than the current value
-func (m Model[T]) Min(field string, value int) Model[T] {
+// Extends the query to update the value of the given field if the given value is less than the current value.
+// The value can be of any comparable BSON type such as a number or a date.
+func (m Model[T]) Min(field string, value any) Model[T] {
 	return m.setUpdateOperator("$min", primitive.M{field: value})
 }
 
-// Extends the query to update the value of the given field if the given value is greater than the current value
-func (m Model[T]) Max(field string, value int) Model[T] {
+// Extends the query to update the value of the given field if the given value is greater than the current value.
+// The value can be of any comparable BSON type such as a number or a date.
+func (m Model[T]) Max(field string, value any) Model[T] {
 	return m.setUpdateOperator("$max", primitive.M{field: value})
 }
 
@@ -266,6 +306,119 @@ func (m Model[T]) Push(field string, values ...any) Model[T] {
 	return m.setUpdateOperator("$push", primitive.M{field: primitive.M{"$each": values}})
 }
 
+// Extends the query with an update expressed as an aggregation pipeline matching the given query(s) merged with the filters of the query.
+// Pipeline updates can compute fields from other fields of the same document and can be conditional. The supported stages are
+// $addFields, $set, $project, $unset, $replaceRoot and $replaceWith. Global scopes such as soft delete apply to the filter.
+// All matching documents are updated with the update many middleware unless One or FindOneAndModify is used, which run the update one
+// and find one and update middleware respectively.
+//
+// Usage:
+//
+//	UserModel.UpdateWithPipeline(&primitive.M{"age": primitive.M{"$gte": 100}},
+//		bson.D{{Key: "$set", Value: primitive.M{"title": primitive.M{"$concat": bson.A{"Elder ", "$name"}}}}},
+//	).Exec()
+func (m Model[T]) UpdateWithPipeline(query *primitive.M, stages ...bson.D) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
+		filters := make(primitive.M)
+		if query != nil {
+			filters = maps.Clone(*query)
+		}
+		maps.Copy(filters, m.findMatchStage())
+		filters = m.withGlobalScopeFilters(filters)
+		var update any = mongo.Pipeline(stages)
+		switch m.updateMode {
+		case updateModeOne:
+			m.middleware.pre.updateOne.run(&update)
+			result, err := m.Collection().UpdateOne(ctx, filters, update, parseUpdateOptions(m, []*options.UpdateOptions{})...)
+			m.middleware.post.updateOne.run(result, err)
+			m.checkConditionsAndPanicForErr(err)
+			return result
+		case updateModeFindOne:
+			var resultDoc T
+			m.middleware.pre.findOneAndUpdate.run(&filters, &update)
+			result := m.Collection().FindOneAndUpdate(ctx, filters, update,
+				parseUpdateOptions(m, []*options.FindOneAndUpdateOptions{})...)
+			m.checkConditionsAndPanic(result)
+			lo.Must0(result.Decode(&resultDoc))
+			m.middleware.post.findOneAndUpdate.run(&resultDoc)
+			return resultDoc
+		default:
+			m.middleware.pre.updateMany.run(&update)
+			result, err := m.Collection().UpdateMany(ctx, filters, update, parseUpdateOptions(m, []*options.UpdateOptions{})...)
+			m.middleware.post.updateMany.run(result, err)
+			m.checkConditionsAndPanicForErr(err)
+			return result
+		}
+	}
+	return m
+}
+
+// Extends the query to update the elements of the given array field which match the given condition.
+// The condition is either a document of conditions on the fields of each element, a document of operators
+// which apply to the element itself such as {"$gte": 10}, or a plain value the element must be equal to.
+// A nil condition matches every element. Likewise, the update is either a document of fields to set on each element
+// or a plain value which replaces the element. It can be chained with other update operators and with itself.
+//
+// Usage:
+//
+//	UserModel.Where("name", "Geralt").UpdateArrayElement("contracts", primitive.M{"monster": "Griffin"}, primitive.M{"completed": true}).Exec()
+//	UserModel.Where("name", "Geralt").UpdateArrayElement("weapons", "Crossbow", "Heavy crossbow").Exec()
+func (m Model[T]) UpdateArrayElement(field string, condition any, update any) Model[T] {
+	path := field + ".$[]"
+	if condition != nil {
+		identifier := fmt.Sprintf("element%d", len(m.queryOptions.arrayFilters))
+		path = field + ".$[" + identifier + "]"
+		filter := primitive.M{}
+		fields, isDocument := condition.(primitive.M)
+		switch {
+		case !isDocument:
+			filter[identifier] = condition
+		case lo.SomeBy(lo.Keys(fields), func(key string) bool { return strings.HasPrefix(key, "$") }):
+			filter[identifier] = fields
+		default:
+			for key, value := range fields {
+				filter[identifier+"."+key] = value
+			}
+		}
+		m = m.ArrayFilters(filter)
+	}
+	set := primitive.M{}
+	if fields, ok := update.(primitive.M); ok {
+		for key, value := range fields {
+			set[path+"."+key] = value
+		}
+	} else {
+		set[path] = update
+	}
+	return m.Set(set)
+}
+
+// Signals chained update operators to update only the first document matching the query.
+// The result of the query is the update result.
+func (m Model[T]) One() Model[T] {
+	m.updateMode = updateModeOne
+	return m
+}
+
+// Signals chained update operators to update all documents matching the query. This is the default.
+// The result of the query is the update result.
+func (m Model[T]) Many() Model[T] {
+	m.updateMode = updateModeMany
+	return m
+}
+
+// Signals chained update operators to update only the first document matching the query and return it.
+// The original document is returned unless New is also used.
+//
+// Usage:
+//
+//	user := UserModel.Where("name", "Geralt").Inc("age", 1).FindOneAndModify().New().ExecT()
+func (m Model[T]) FindOneAndModify() Model[T] {
+	m.updateMode = updateModeFindOne
+	return m
+}
+
 // Signals the query to insert a new document if no documents match the query
 func (m Model[T]) Upsert() Model[T] {
 	m.upsert = true
@@ -0,0 +1,188 @@
+package elemental
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// A field of one model which references documents of another model and has an on delete action.
+type reference struct {
+	field  string // The bson name of the field
+	action OnDeleteAction
+	slice  bool // Whether the field holds an array of references
+}
+
+// Implemented by every model so that the on delete actions of models referencing a deleted document can be enforced without knowing their type.
+type referencingModel interface {
+	referencesTo(model string) []reference
+	countReferences(ctx context.Context, database string, ref reference, ids []any) int64
+	nullifyReferences(ctx context.Context, database string, ref reference, ids []any)
+	cascadeReferences(ctx context.Context, database string, ref reference, ids []any)
+	modelName() string
+}
+
+func (m Model[T]) modelName() string {
+	return m.Name
+}
+
+// Returns the fields of this model which reference the given model and have an on delete action.
+func (m Model[T]) referencesTo(model string) []reference {
+	var references []reference
+	for name, definition := range m.Schema.Definitions {
+		if definition.Ref != model || definition.OnDelete == NoAction {
+			continue
+		}
+		field := strings.ToLower(name)
+		if m.docReflectType.Kind() != reflect.Struct {
+			references = append(references, reference{field: field, action: definition.OnDelete, slice: isSliceField(definition)})
+			continue
+		}
+		if reflected, ok := m.docReflectType.FieldByName(name); ok {
+			field = lo.CoalesceOrEmpty(cleanTag(reflected.Tag.Get("bson")), field)
+		}
+		references = append(references, reference{field: field, action: definition.OnDelete, slice: isSliceField(definition)})
+	}
+	return references
+}
+
+// Counts the documents of this model which hold any of the given references. Soft deleted documents are not counted.
+func (m Model[T]) countReferences(ctx context.Context, database string, ref reference, ids []any) int64 {
+	return cast.ToInt64(m.SetDatabase(database).CountDocuments(primitive.M{ref.field: primitive.M{"$in": ids}}).Exec(ctx))
+}
+
+// Removes the given references from the documents of this model, including soft deleted ones.
+func (m Model[T]) nullifyReferences(ctx context.Context, database string, ref reference, ids []any) {
+	m = m.SetDatabase(database).WithTrashed()
+	if ref.slice {
+		m.Where(ref.field).In(ids...).Pull(ref.field, primitive.M{"$in": ids}).Exec(ctx)
+		return
+	}
+	m.UpdateMany(&primitive.M{ref.field: primitive.M{"$in": ids}}, primitive.M{ref.field: nil}).Exec(ctx)
+}
+
+// Deletes the documents of this model which hold any of the given references through DeleteMany,
+// so that they are soft deleted if this model has soft delete enabled and their own references are acted upon as well.
+func (m Model[T]) cascadeReferences(ctx context.Context, database string, ref reference, ids []any) {
+	m.SetDatabase(database).DeleteMany(primitive.M{ref.field: primitive.M{"$in": ids}}).Exec(ctx)
+}
+
+// Extends the query to run a delete within a transaction along with the on delete actions of the models referencing the deleted documents,
+// so that either all of them take effect or none. Has no effect if the query already runs within a session.
+//
+// Usage:
+//
+//	KingdomModel.InTransaction().DeleteByID(id).Exec()
+func (m Model[T]) InTransaction() Model[T] {
+	m.transactional = true
+	return m
+}
+
+// Builds a delete with the given builder and wraps its executor so that the on delete actions of the models referencing the documents it deletes are enforced first.
+// The delete is rebuilt to match only the documents whose references were acted upon, even if other documents match the query by the time it runs.
+// The executor is left as is if no model references this one.
+func (m Model[T]) withReferentialActions(query primitive.M, build func(Model[T], primitive.M) Model[T], one bool) Model[T] {
+	m = build(m, query)
+	if len(m.referencingModels()) == 0 {
+		return m
+	}
+	m.executor = func(m Model[T], ctx context.Context) any {
+		run := func(ctx context.Context) any {
+			ids := m.deletedIDs(ctx, query, one)
+			m.enforceReferentialActions(ctx, ids)
+			m = build(m, mergeFilters(maps.Clone(query), primitive.M{"_id": primitive.M{"$in": ids}}))
+			return m.executor(m, ctx)
+		}
+		if !m.transactional || mongo.SessionFromContext(ctx) != nil {
+			return run(ctx)
+		}
+		session := lo.Must(m.Connection().StartSession())
+		defer session.EndSession(context.WithoutCancel(ctx))
+		var failure any
+		result, err := session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (result any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					failure = r
+					err = toError(r)
+				}
+			}()
+			return run(sessionCtx), nil
+		})
+		if failure != nil {
+			panic(failure)
+		}
+		m.checkConditionsAndPanicForErr(err)
+		return result
+	}
+	return m
+}
+
+// Returns all registered models which have a field referencing this model with an on delete action, ordered by name.
+func (m Model[T]) referencingModels() []referencingModel {
+	var models []referencingModel
+	for _, model := range Models {
+		if r, ok := model.(referencingModel); ok && len(r.referencesTo(m.Name)) > 0 {
+			models = append(models, r)
+		}
+	}
+	slices.SortFunc(models, func(a, b referencingModel) int { return strings.Compare(a.modelName(), b.modelName()) })
+	return models
+}
+
+// Returns the ids of the documents the given delete query matches, or only the first one of them if one is set.
+func (m Model[T]) deletedIDs(ctx context.Context, query primitive.M, one bool) []any {
+	filters := maps.Clone(query)
+	maps.Copy(filters, m.findMatchStage())
+	filters = m.withGlobalScopeFilters(filters)
+	opts := options.Find().SetProjection(primitive.M{"_id": 1})
+	if one {
+		opts.SetLimit(1)
+	}
+	var docs []bson.M
+	cursor := lo.Must(m.Collection().Find(ctx, filters, opts))
+	m.checkConditionsAndPanicForErr(cursor.All(ctx, &docs))
+	return lo.Map(docs, func(doc bson.M, _ int) any { return doc["_id"] })
+}
+
+// Enforces the on delete actions of all models referencing the documents with the given ids.
+// Referencing documents are expected to live in the same database as the deleted ones.
+// Restrictions are checked for every referencing model before any referencing document is modified.
+func (m Model[T]) enforceReferentialActions(ctx context.Context, ids []any) {
+	if len(ids) == 0 {
+		return
+	}
+	type target struct {
+		model referencingModel
+		ref   reference
+	}
+	var targets []target
+	for _, model := range m.referencingModels() {
+		for _, ref := range model.referencesTo(m.Name) {
+			targets = append(targets, target{model: model, ref: ref})
+		}
+	}
+	database := m.Collection().Database().Name()
+	for _, t := range targets {
+		if t.ref.action == Restrict && t.model.countReferences(ctx, database, t.ref, ids) > 0 {
+			panic(fmt.Errorf("%w by %s.%s", ErrDeleteRestricted, t.model.modelName(), t.ref.field))
+		}
+	}
+	for _, t := range targets {
+		switch t.ref.action {
+		case SetNull:
+			t.model.nullifyReferences(ctx, database, t.ref, ids)
+		case Cascade:
+			t.model.cascadeReferences(ctx, database, t.ref, ids)
+		}
+	}
+}
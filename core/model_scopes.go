@@ -0,0 +1,193 @@
+package elemental
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The name of the global scope which filters out soft deleted documents while soft delete is enabled.
+const SoftDeleteScope = "softDelete"
+
+type scopeRegistry[T any] struct {
+	named  map[string]func(m Model[T]) Model[T]
+	global []string // The names of the scopes which apply to every query, in the order they were added
+}
+
+func newScopeRegistry[T any]() *scopeRegistry[T] {
+	return &scopeRegistry[T]{named: make(map[string]func(m Model[T]) Model[T])}
+}
+
+// Registers a named scope on this model, which can be applied to any query of the model with Scoped.
+// Registering a scope with the name of an existing one replaces it.
+//
+// Usage:
+//
+//	UserModel.AddScope("retired", func(m elemental.Model[User]) elemental.Model[User] {
+//		return m.Where("retired", true)
+//	})
+func (m Model[T]) AddScope(name string, scope func(m Model[T]) Model[T]) Model[T] {
+	m.scopes.named[name] = scope
+	return m
+}
+
+// Registers a named scope on this model which is applied to every read, update and delete of the model.
+// Only the filters a global scope adds are applied, and they are combined with the filters of each query.
+// A query can opt out of a global scope with WithoutScope, and it can still be applied explicitly with Scoped.
+//
+// Usage:
+//
+//	UserModel.AddGlobalScope("active", func(m elemental.Model[User]) elemental.Model[User] {
+//		return m.Where("retired", false)
+//	})
+func (m Model[T]) AddGlobalScope(name string, scope func(m Model[T]) Model[T]) Model[T] {
+	m.scopes.named[name] = scope
+	if !lo.Contains(m.scopes.global, name) {
+		m.scopes.global = append(m.scopes.global, name)
+	}
+	return m
+}
+
+// Removes a named or global scope from this model.
+func (m Model[T]) RemoveScope(name string) Model[T] {
+	delete(m.scopes.named, name)
+	m.scopes.global = lo.Without(m.scopes.global, name)
+	return m
+}
+
+// Extends the query with the given named scopes, which are applied in the given order.
+// Panics with ErrUnknownScope if any of the scopes has not been registered.
+//
+// Usage:
+//
+//	UserModel.Find().Scoped("retired", "witchers").ExecTT()
+func (m Model[T]) Scoped(names ...string) Model[T] {
+	for _, name := range names {
+		scope, ok := m.scopes.named[name]
+		if !ok {
+			panic(fmt.Errorf("%w: %s", ErrUnknownScope, name))
+		}
+		m = scope(m)
+	}
+	return m
+}
+
+// Excludes the given global scopes from this query. Pass SoftDeleteScope to include soft deleted documents.
+//
+// Usage:
+//
+//	UserModel.Find().WithoutScope(elemental.SoftDeleteScope).ExecTT()
+func (m Model[T]) WithoutScope(names ...string) Model[T] {
+	m.excludedScopes = lo.Union(m.excludedScopes, names)
+	return m
+}
+
+// Returns the global scopes which apply to this query, starting with the tenant scope in field mode and the soft delete scope if soft delete is enabled.
+// The tenant scope cannot be excluded with WithoutScope.
+func (m Model[T]) activeGlobalScopes() []func(m Model[T]) Model[T] {
+	var scopes []func(m Model[T]) Model[T]
+	if m.tenant != nil {
+		scopes = append(scopes, func(m Model[T]) Model[T] {
+			return m.Where(m.tenantField(), m.tenant)
+		})
+	}
+	if m.softDeleteEnabled && !lo.Contains(m.excludedScopes, SoftDeleteScope) {
+		scopes = append(scopes, func(m Model[T]) Model[T] {
+			return m.Where(m.deletedAtFieldName).Exists(false)
+		})
+	}
+	if m.scopes == nil {
+		return scopes
+	}
+	for _, name := range m.scopes.global {
+		if !lo.Contains(m.excludedScopes, name) {
+			scopes = append(scopes, m.scopes.named[name])
+		}
+	}
+	return scopes
+}
+
+// Collects the filters of all global scopes which apply to this query into a single filter.
+func (m Model[T]) globalScopeFilter() primitive.M {
+	filter := primitive.M{}
+	for _, scope := range m.activeGlobalScopes() {
+		base := m
+		base.pipeline = nil
+		base.whereField = ""
+		base.orConditionActive, base.notConditionActive = false, false
+		for _, stage := range scope(base).pipeline {
+			if stage[0].Key == "$match" {
+				filter = mergeFilters(filter, utils.CastBSON[primitive.M](stage[0].Value))
+			}
+		}
+	}
+	return filter
+}
+
+// Returns the given pipeline with a match stage holding the filters of all global scopes which apply to this query.
+// The stage is placed at the start of the pipeline, after any stage which must come first.
+// If the pipeline already starts with a match stage, such as the one of a text search which must remain first, the filters are merged into it instead.
+func (m Model[T]) withGlobalScopes(pipeline mongo.Pipeline) mongo.Pipeline {
+	filter := m.globalScopeFilter()
+	if len(filter) == 0 {
+		return pipeline
+	}
+	index := 0
+	if len(pipeline) > 0 && lo.Contains(leadingStages, pipeline[0][0].Key) {
+		index = 1
+	}
+	pipeline = slices.Clone(pipeline)
+	if len(pipeline) > index && pipeline[index][0].Key == "$match" {
+		pipeline[index] = bson.D{{Key: "$match", Value: mergeFilters(utils.CastBSON[primitive.M](pipeline[index][0].Value), filter)}}
+		return pipeline
+	}
+	return slices.Insert(pipeline, index, bson.D{{Key: "$match", Value: filter}})
+}
+
+// Returns the given write filters combined with the filters of all global scopes which apply to this query.
+// The given filters are not modified.
+func (m Model[T]) withGlobalScopeFilters(filters primitive.M) primitive.M {
+	return mergeFilters(maps.Clone(filters), m.globalScopeFilter())
+}
+
+// Merges the conditions of the given filter into the target filter, moving conditions on a field the target already filters on into an $and.
+func mergeFilters(target, filter primitive.M) primitive.M {
+	if target == nil {
+		target = primitive.M{}
+	}
+	for key, value := range filter {
+		existing, ok := target[key]
+		switch {
+		case !ok:
+			target[key] = value
+		case key == "$and":
+			target[key] = append(filterClauses(existing), filterClauses(value)...)
+		default:
+			target["$and"] = append(filterClauses(target["$and"]), primitive.M{key: value})
+		}
+	}
+	return target
+}
+
+// Returns the clauses of an $and or $or operator as a generic slice, regardless of the slice type they were built with.
+func filterClauses(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return slices.Clone(v)
+	case bson.A:
+		return slices.Clone(v)
+	case []primitive.M:
+		return lo.ToAnySlice(v)
+	case []bson.D:
+		return lo.ToAnySlice(v)
+	}
+	return []any{value}
+}
@@ -0,0 +1,60 @@
+package elemental
+
+import (
+	"slices"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SearchOptions struct {
+	Language           string // The language that determines the stop words, stemmer and tokenizer. Defaults to the default language of the text index
+	CaseSensitive      bool   // Whether to perform a case sensitive search
+	DiacriticSensitive bool   // Whether to perform a diacritic sensitive search. E.g. whether "é" and "e" are treated as different characters
+	SortByScore        bool   // Whether to sort the results by their relevance score in descending order
+	ScoreField         string // The name of the field to project the relevance score into. The score is not projected if this is empty
+}
+
+// Extends the query with a full text search on the text index of the collection.
+// The schema of the model should declare at least one field with TextIndex set to true for this to work.
+//
+// Usage:
+//
+//	UserModel.Search("witcher", elemental.SearchOptions{SortByScore: true, ScoreField: "score"})
+func (m Model[T]) Search(term string, opts ...SearchOptions) Model[T] {
+	searchOpts := lo.FirstOrEmpty(opts)
+	text := primitive.M{"$search": term}
+	if searchOpts.Language != "" {
+		text["$language"] = searchOpts.Language
+	}
+	if searchOpts.CaseSensitive {
+		text["$caseSensitive"] = true
+	}
+	if searchOpts.DiacriticSensitive {
+		text["$diacriticSensitive"] = true
+	}
+	// A text search must be within the first stage of the pipeline
+	m.pipeline = slices.Clone(m.pipeline)
+	if len(m.pipeline) > 0 && m.pipeline[0][0].Key == "$match" {
+		filters := lo.Assign(utils.Cast[primitive.M](utils.CastBSON[bson.M](m.pipeline[0])["$match"]))
+		filters["$text"] = text
+		m.pipeline[0] = bson.D{{Key: "$match", Value: filters}}
+	} else {
+		m.pipeline = slices.Insert(m.pipeline, 0, bson.D{{Key: "$match", Value: primitive.M{"$text": text}}})
+	}
+	if searchOpts.ScoreField != "" {
+		m.pipeline = append(m.pipeline, bson.D{{Key: "$addFields", Value: primitive.M{searchOpts.ScoreField: TextScore()}}})
+	}
+	if searchOpts.SortByScore {
+		m = m.addToPipeline("$sort", lo.CoalesceOrEmpty(searchOpts.ScoreField, "score"), TextScore())
+	}
+	return m
+}
+
+// Returns the meta expression which resolves to the relevance score of a document within a full text search.
+// It can be used within custom projection or sort stages following a Search.
+func TextScore() primitive.M {
+	return primitive.M{"$meta": "textScore"}
+}
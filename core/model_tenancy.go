@@ -0,0 +1,73 @@
+package elemental
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// The context key which holds the tenant of a query for models with tenancy enabled.
+type tenantKey struct{}
+
+// The field which holds the tenant of a document in field mode unless another one is specified.
+const DefaultTenantField = "tenant_id"
+
+// Returns a copy of the given context which carries the given tenant, within which queries of models with tenancy enabled are run.
+//
+// Usage:
+//
+//	ctx := elemental.WithTenant(context.Background(), "kaer-morhen")
+//	contracts := ContractModel.Find().ExecTT(ctx)
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Returns the tenant carried by the given context, or nil if it does not carry one.
+func TenantFrom(ctx context.Context) any {
+	return ctx.Value(tenantKey{})
+}
+
+// Resolves the tenant of this query from the given context for models with tenancy enabled.
+// In field mode the tenant filter is added to the global scopes of the query, whereas in database mode the query is routed to the database of the tenant.
+// Panics with ErrTenantRequired if the context does not carry a tenant, so that a query can never reach the documents of all tenants.
+func (m Model[T]) withTenant(ctx context.Context) Model[T] {
+	tenancy := m.Schema.Options.Tenancy
+	if tenancy == nil {
+		return m
+	}
+	tenant := TenantFrom(ctx)
+	if utils.IsEmpty(tenant) {
+		panic(ErrTenantRequired)
+	}
+	if tenancy.Mode == TenancyModeDatabase {
+		if tenancy.Database != nil {
+			return m.SetDatabase(tenancy.Database(tenant))
+		}
+		return m.SetDatabase(fmt.Sprint(tenant))
+	}
+	m.tenant = tenant
+	return m
+}
+
+// Returns the field which holds the tenant of a document in field mode.
+func (m Model[T]) tenantField() string {
+	return lo.CoalesceOrEmpty(m.Schema.Options.Tenancy.Field, DefaultTenantField)
+}
+
+// Sets the tenant of this query on a document which is about to be inserted or which replaces another.
+// Panics with ErrTenantMismatch if the document already belongs to another tenant.
+func (m Model[T]) stampTenant(doc bson.M) bson.M {
+	if m.tenant == nil {
+		return doc
+	}
+	field := m.tenantField()
+	if existing, ok := doc[field]; ok && !utils.IsEmpty(existing) && !reflect.DeepEqual(existing, m.tenant) {
+		panic(ErrTenantMismatch)
+	}
+	doc[field] = m.tenant
+	return doc
+}
@@ -16,3 +16,22 @@ type facetResult[T any] struct {
 	Docs  []T                `bson:"docs"`
 	Count []map[string]int64 `bson:"count"`
 }
+
+type CursorPaginateResult[T any] struct {
+	Docs       []T     `json:"docs"`       // The documents returned by the query
+	TotalDocs  *int64  `json:"totalDocs"`  // The total number of documents which match the query. Nil if the count was skipped
+	Limit      int64   `json:"limit"`      // The maximum number of documents per page
+	NextCursor *string `json:"nextCursor"` // An opaque token which can be used to fetch the next page if there is one
+	PrevCursor *string `json:"prevCursor"` // An opaque token which can be used to fetch the previous page if there is one
+	HasPrev    bool    `json:"hasPrev"`    // Whether there is a previous page or not
+	HasNext    bool    `json:"hasNext"`    // Whether there is a next page or not
+}
+
+// Determines how chained update operators are executed
+type updateMode int
+
+const (
+	updateModeMany    updateMode = iota // Updates all matching documents
+	updateModeOne                       // Updates the first matching document
+	updateModeFindOne                   // Updates the first matching document and returns it
+)
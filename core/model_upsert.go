@@ -0,0 +1,153 @@
+package elemental
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UpsertResult[T any] struct {
+	Document T    `json:"document"` // The matched document, or the newly created one
+	Created  bool `json:"created"`  // Whether the document was created rather than matched
+}
+
+// Extends the query to atomically find the first document matching the given filter, creating it from the given document if there is none.
+// The equality conditions of the filter are copied into the document before it is validated against the model schema,
+// hence they need not be repeated in the document. An existing document is returned as is without being modified.
+// Since the document is prepared before it is known whether a match exists, pre save middleware runs on every execution
+// and can modify the document to be created, whereas post save middleware only runs once the document has been created.
+// The result of the query is an UpsertResult.
+//
+// Usage:
+//
+//	result := UserModel.FindOrCreate(primitive.M{"name": "Geralt"}, User{Age: 100}).Exec().(elemental.UpsertResult[User])
+func (m Model[T]) FindOrCreate(filter primitive.M, doc T) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
+		documentToInsert := m.stampTenant(enforceSchema(m.Schema, lo.ToPtr(withUpsertSeeds(filter, doc)), nil))
+		m.middleware.pre.save.run(&documentToInsert)
+		result := m.Collection().FindOneAndUpdate(ctx, m.withGlobalScopeFilters(filter), primitive.M{"$setOnInsert": documentToInsert},
+			append(parseUpdateOptions(m, []*options.FindOneAndUpdateOptions{}),
+				options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before))...)
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			m.middleware.post.save.run(&documentToInsert)
+			return UpsertResult[T]{Document: utils.CastBSON[T](documentToInsert), Created: true}
+		}
+		var existing T
+		m.checkConditionsAndPanicForErr(result.Decode(&existing))
+		return UpsertResult[T]{Document: existing}
+	}
+	return m
+}
+
+// Extends the query to atomically update the first document matching the given filter, creating it if there is none.
+// The update is applied with $set. On creation, the equality conditions of the filter and the update together make up the new document,
+// which is validated against the model schema and has its defaults applied through $setOnInsert. An existing document is updated without being validated.
+// The result of the query is an UpsertResult holding the updated or created document.
+//
+// Usage:
+//
+//	result := UserModel.UpdateOrCreate(primitive.M{"name": "Geralt"}, primitive.M{"age": 101}).Exec().(elemental.UpsertResult[User])
+func (m Model[T]) UpdateOrCreate(filter primitive.M, update any) Model[T] {
+	m.executor = func(m Model[T], ctx context.Context) any {
+		defer m.invalidateCache()
+		filters := m.withGlobalScopeFilters(filter)
+		m.middleware.pre.findOneAndUpdate.run(&filters, &update)
+		set := m.parseDocument(update)
+		// An existing document is updated as is, since the schema only has to be enforced on a document which is about to be created
+		updated := m.Collection().FindOneAndUpdate(ctx, filters, primitive.M{"$set": set},
+			append(parseUpdateOptions(m, []*options.FindOneAndUpdateOptions{}), options.FindOneAndUpdate().SetReturnDocument(options.After))...)
+		if !errors.Is(updated.Err(), mongo.ErrNoDocuments) {
+			var resultDoc T
+			m.checkConditionsAndPanicForErr(updated.Decode(&resultDoc))
+			m.middleware.post.findOneAndUpdate.run(&resultDoc)
+			return UpsertResult[T]{Document: resultDoc}
+		}
+		documentToInsert := m.stampTenant(enforceSchema(m.Schema, lo.ToPtr(withUpsertSeeds(filters, utils.CastBSON[T](set))), nil))
+		if utils.IsEmpty(documentToInsert["_id"]) {
+			documentToInsert["_id"] = primitive.NewObjectID()
+		}
+		setOnInsert := lo.OmitBy(documentToInsert, func(key string, _ any) bool {
+			return lo.SomeBy(lo.Keys(set), func(path string) bool {
+				return path == key || strings.HasPrefix(path, key+".")
+			})
+		})
+		// When the filter pins the _id, the document returned after the update cannot tell whether it has been created,
+		// so the document before the update is requested instead and the updated one is read back if it existed.
+		_, pinnedID := filters["_id"]
+		returnDocument := lo.Ternary(pinnedID, options.Before, options.After)
+		result := m.Collection().FindOneAndUpdate(ctx, filters, primitive.M{"$set": set, "$setOnInsert": setOnInsert},
+			append(parseUpdateOptions(m, []*options.FindOneAndUpdateOptions{}),
+				options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(returnDocument))...)
+		var resultDoc T
+		created := false
+		switch {
+		case pinnedID && errors.Is(result.Err(), mongo.ErrNoDocuments):
+			created = true
+			resultDoc = utils.CastBSON[T](documentToInsert)
+		case pinnedID:
+			m.checkConditionsAndPanicForErr(result.Err())
+			m.checkConditionsAndPanicForErr(m.Collection().FindOne(ctx, primitive.M{"_id": filters["_id"]}).Decode(&resultDoc))
+		default:
+			m.checkConditionsAndPanicForErr(result.Decode(&resultDoc))
+			created = utils.CastBSON[bson.M](resultDoc)["_id"] == documentToInsert["_id"]
+		}
+		m.middleware.post.findOneAndUpdate.run(&resultDoc)
+		return UpsertResult[T]{Document: resultDoc, Created: created}
+	}
+	return m
+}
+
+// Returns a bulk write builder which upserts each of the given documents, matching existing documents by the given key fields.
+// The key fields are the bson names of the fields and default to _id. Matched documents are updated with all fields of
+// the given document except for the _id and the creation timestamp, which are only set when the document is created.
+// The index of every created document is present in the UpsertedIDs map of the result, every other succeeded index was matched.
+//
+// Usage:
+//
+//	result := UserModel.UpsertMany(users, "name").Exec()
+func (m Model[T]) UpsertMany(docs []T, keyFields ...string) Bulk[T] {
+	bulk := m.Bulk()
+	for _, doc := range docs {
+		bulk = bulk.Upsert(doc, keyFields...)
+	}
+	return bulk
+}
+
+// Returns the bson names of the fields which must only be set when a document is created, which are the _id and the creation timestamp.
+func (m Model[T]) insertOnlyFields() []string {
+	fields := []string{"_id"}
+	if m.docReflectType.Kind() != reflect.Struct {
+		return fields
+	}
+	if field, ok := m.docReflectType.FieldByName("CreatedAt"); ok {
+		fields = append(fields, cleanTag(field.Tag.Get("bson")))
+	}
+	return fields
+}
+
+// Copies the equality conditions of the given filter into the fields of the given document which are empty.
+// These are the fields an upsert copies from its filter into a newly inserted document.
+func withUpsertSeeds[T any](filter primitive.M, doc T) T {
+	result := utils.CastBSON[bson.M](doc)
+	for key, value := range filter {
+		if strings.HasPrefix(key, "$") || strings.Contains(key, ".") || !utils.IsEmpty(result[key]) {
+			continue
+		}
+		if condition, ok := value.(primitive.M); ok && lo.SomeBy(lo.Keys(condition), func(k string) bool {
+			return strings.HasPrefix(k, "$")
+		}) {
+			continue
+		}
+		result[key] = value
+	}
+	return utils.CastBSON[T](result)
+}
@@ -2,11 +2,16 @@ package elemental
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"math/big"
 	"reflect"
+	"strings"
 
 	"github.com/elcengine/elemental/utils"
 
 	"github.com/samber/lo"
+	"github.com/spf13/cast"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -16,6 +21,11 @@ import (
 func (m Model[T]) addToFilters(key string, value any) Model[T] {
 	stage := "$match"
 	foundMatchStage := false
+	condition := primitive.M{key: value}
+	if m.notConditionActive {
+		condition = primitive.M{"$not": condition}
+		m.notConditionActive = false
+	}
 	m.pipeline = lo.Map(m.pipeline, func(stg bson.D, _ int) bson.D {
 		filters := utils.Cast[primitive.M](utils.CastBSON[bson.M](stg)[stage])
 		if filters != nil {
@@ -23,10 +33,10 @@ func (m Model[T]) addToFilters(key string, value any) Model[T] {
 			if m.orConditionActive {
 				if filters["$or"] == nil {
 					filters["$or"] = []primitive.M{
-						{m.whereField: primitive.M{key: value}},
+						{m.whereField: condition},
 					}
 				} else {
-					filters["$or"] = append(filters["$or"].([]primitive.M), primitive.M{m.whereField: primitive.M{key: value}})
+					filters["$or"] = append(filters["$or"].([]primitive.M), primitive.M{m.whereField: condition})
 				}
 				for k, v := range filters {
 					if k != "$or" {
@@ -41,18 +51,19 @@ func (m Model[T]) addToFilters(key string, value any) Model[T] {
 					for _, filter := range filters["$and"].([]primitive.M) {
 						if filter[m.whereField] != nil {
 							filterExistsWithinAndOperator = true
-							filters["$and"] = append(filters["$and"].([]primitive.M), primitive.M{m.whereField: primitive.M{key: value}})
+							filters["$and"] = append(filters["$and"].([]primitive.M), primitive.M{m.whereField: condition})
 						}
 					}
 				}
 				if !filterExistsWithinAndOperator {
 					if filters[m.whereField] == nil {
-						filters[m.whereField] = primitive.M{key: value}
+						filters[m.whereField] = condition
 					} else {
-						filters["$and"] = []primitive.M{
-							{m.whereField: filters[m.whereField]},
-							{m.whereField: primitive.M{key: value}},
-						}
+						and, _ := filters["$and"].([]primitive.M)
+						filters["$and"] = append(and,
+							primitive.M{m.whereField: filters[m.whereField]},
+							primitive.M{m.whereField: condition},
+						)
 						delete(filters, m.whereField)
 					}
 				}
@@ -62,7 +73,7 @@ func (m Model[T]) addToFilters(key string, value any) Model[T] {
 		return stg
 	})
 	if !foundMatchStage {
-		m.pipeline = append(m.pipeline, bson.D{{Key: stage, Value: primitive.M{m.whereField: primitive.M{key: value}}}})
+		m.pipeline = append(m.pipeline, bson.D{{Key: stage, Value: primitive.M{m.whereField: condition}}})
 		return m
 	}
 	return m
@@ -88,10 +99,28 @@ func (m Model[T]) addToPipeline(stage, key string, value any) Model[T] {
 	return m
 }
 
+// Returns the given pipeline as it is sent to the server, with the global scopes of this query
+// and those of any populated models applied within the tenant of the given context.
+func (m Model[T]) finalPipeline(ctx context.Context, pipeline mongo.Pipeline) mongo.Pipeline {
+	return m.withGlobalScopes(resolvePopulateScopes(ctx, pipeline))
+}
+
+// Runs the given pipeline against the collection of this model.
+// All read executors should go through this method instead of calling the driver directly, since it applies the global scopes of the query.
+func (m Model[T]) aggregate(ctx context.Context, pipeline mongo.Pipeline) (*mongo.Cursor, error) {
+	pipeline = m.finalPipeline(ctx, pipeline)
+	recordPipeline(ctx, pipeline)
+	m.warnOnCollectionScan(ctx, pipeline)
+	if store := currentCacheStore(); m.cacheTTL != nil && store != nil && mongo.SessionFromContext(ctx) == nil {
+		return m.cachedAggregate(ctx, store, pipeline)
+	}
+	return m.Collection().Aggregate(ctx, pipeline, m.aggregateOptions())
+}
+
 func (m Model[T]) checkConditionsAndPanic(result any) {
 	if m.failWith != nil {
 		val := reflect.ValueOf(result)
-		if val.Kind() == reflect.Ptr {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
 			val = val.Elem()
 		}
 		if (val.Kind() == reflect.Slice || val.Kind() == reflect.Array) && val.Len() == 0 {
@@ -142,16 +171,13 @@ func (m Model[T]) parseDocument(doc any) bson.M {
 
 func parseUpdateOptions[T any, O any](m Model[T], opts []*O) []*O {
 	setOptions := func(option string, value any) {
+		if !reflect.ValueOf(new(O)).MethodByName(option).IsValid() {
+			return // Not every operation supports every option
+		}
 		if len(opts) == 0 {
-			var emptyOptionInstance *O
-			options := reflect.New(reflect.TypeOf(emptyOptionInstance).Elem())
-			options.MethodByName(option).Call([]reflect.Value{reflect.ValueOf(value)})
-			opts = append(opts, options.Interface().(*O))
-		} else {
-			options := reflect.ValueOf(opts[0]).Elem()
-			options.MethodByName(option).Call([]reflect.Value{reflect.ValueOf(value)})
-			opts[0] = options.Interface().(*O)
+			opts = append(opts, new(O))
 		}
+		reflect.ValueOf(opts[0]).MethodByName(option).Call([]reflect.Value{reflect.ValueOf(value)})
 	}
 	if m.upsert {
 		setOptions("SetUpsert", true)
@@ -159,24 +185,165 @@ func parseUpdateOptions[T any, O any](m Model[T], opts []*O) []*O {
 	if m.returnNew {
 		setOptions("SetReturnDocument", options.After)
 	}
+	if m.queryOptions.hint != nil {
+		setOptions("SetHint", m.queryOptions.hint)
+	}
+	if m.queryOptions.maxTime != nil {
+		setOptions("SetMaxTime", *m.queryOptions.maxTime)
+	}
+	if m.queryOptions.comment != nil {
+		setOptions("SetComment", *m.queryOptions.comment)
+	}
+	if len(m.queryOptions.arrayFilters) > 0 {
+		setOptions("SetArrayFilters", options.ArrayFilters{Filters: m.queryOptions.arrayFilters})
+	}
 	return opts
 }
 
+// Accumulates the given fields into the given update operator so that chained operators are executed as a single update.
+// Values added to the same array field with $push or $addToSet more than once are combined with $each.
 func (m Model[T]) setUpdateOperator(operator string, doc any) Model[T] {
+	operators := maps.Clone(m.updateOperators)
+	if operators == nil {
+		operators = make(map[string]primitive.M)
+	}
+	fields := maps.Clone(operators[operator])
+	if fields == nil {
+		fields = primitive.M{}
+	}
+	for field, value := range m.parseDocument(doc) {
+		if existing, ok := fields[field]; ok && (operator == "$push" || operator == "$addToSet") {
+			existingValues, existingOk := eachValues(existing)
+			newValues, newOk := eachValues(value)
+			if existingOk && newOk {
+				value = primitive.M{"$each": append(existingValues, newValues...)}
+			}
+		}
+		fields[field] = value
+	}
+	operators[operator] = fields
+	m.updateOperators = operators
 	m.executor = func(m Model[T], ctx context.Context) any {
-		return (func() any {
-			result, err := m.Collection().UpdateMany(ctx, m.findMatchStage(), primitive.M{operator: m.parseDocument(doc)})
+		defer m.invalidateCache()
+		var update any = primitive.M{}
+		for operator, fields := range m.updateOperators {
+			update.(primitive.M)[operator] = fields
+		}
+		filters := m.withGlobalScopeFilters(m.findMatchStage())
+		switch m.updateMode {
+		case updateModeOne:
+			m.middleware.pre.updateOne.run(&update)
+			result, err := m.Collection().UpdateOne(ctx, filters, update, parseUpdateOptions(m, []*options.UpdateOptions{})...)
+			m.middleware.post.updateOne.run(result, err)
+			m.checkConditionsAndPanicForErr(err)
+			return result
+		case updateModeFindOne:
+			var resultDoc T
+			m.middleware.pre.findOneAndUpdate.run(&filters, &update)
+			result := m.Collection().FindOneAndUpdate(ctx, filters, update,
+				parseUpdateOptions(m, []*options.FindOneAndUpdateOptions{})...)
+			m.checkConditionsAndPanic(result)
+			lo.Must0(result.Decode(&resultDoc))
+			m.middleware.post.findOneAndUpdate.run(&resultDoc)
+			return resultDoc
+		default:
+			m.middleware.pre.updateMany.run(&update)
+			result, err := m.Collection().UpdateMany(ctx, filters, update, parseUpdateOptions(m, []*options.UpdateOptions{})...)
+			m.middleware.post.updateMany.run(result, err)
 			m.checkConditionsAndPanicForErr(err)
 			return result
-		})()
+		}
 	}
 	return m
 }
 
+// Returns the values of a $push or $addToSet field, which is either a single value or a plain $each modifier.
+// Returns false if the field has modifiers besides $each, in which case it cannot be combined with other values.
+func eachValues(value any) ([]any, bool) {
+	modifiers, ok := value.(primitive.M)
+	if !ok {
+		return []any{value}, true
+	}
+	each, ok := modifiers["$each"]
+	if !ok {
+		return []any{value}, true
+	}
+	if len(modifiers) > 1 {
+		return nil, false
+	}
+	values := reflect.ValueOf(each)
+	if values.Kind() != reflect.Slice {
+		return nil, false
+	}
+	result := make([]any, values.Len())
+	for i := range result {
+		result[i] = values.Index(i).Interface()
+	}
+	return result, true
+}
+
+// Panics if the given value is not a number which can be used with arithmetic update operators.
+func ensureNumeric(value any) any {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return value
+	}
+	if _, ok := value.(primitive.Decimal128); ok {
+		return value
+	}
+	panic(fmt.Errorf("%w: %v", ErrNotNumeric, value))
+}
+
+// Returns the reciprocal of the given number. Decimals keep their type, whereas all other numbers become floats.
+func reciprocal(value any) any {
+	if decimal, ok := ensureNumeric(value).(primitive.Decimal128); ok {
+		divisor, ok := new(big.Float).SetPrec(decimalPrecision).SetString(decimal.String())
+		if !ok {
+			panic(fmt.Errorf("%w: %v", ErrNotNumeric, value))
+		}
+		if divisor.Sign() == 0 {
+			panic(ErrDivisionByZero)
+		}
+		result := new(big.Float).SetPrec(decimalPrecision).Quo(big.NewFloat(1), divisor)
+		return lo.Must(primitive.ParseDecimal128(result.Text('g', 34)))
+	}
+	divisor := cast.ToFloat64(value)
+	if divisor == 0 {
+		panic(ErrDivisionByZero)
+	}
+	return 1 / divisor
+}
+
+// The precision in bits used for arithmetic on decimals, which is enough for the 34 significant digits of a Decimal128.
+const decimalPrecision = 128
+
+// Returns the negation of the given number, keeping its type where possible.
+func negate(value any) any {
+	val := reflect.ValueOf(ensureNumeric(value))
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(-val.Int()).Convert(val.Type()).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return -int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(-val.Float()).Convert(val.Type()).Interface()
+	}
+	decimal := value.(primitive.Decimal128).String()
+	if strings.HasPrefix(decimal, "-") {
+		return lo.Must(primitive.ParseDecimal128(decimal[1:]))
+	}
+	return lo.Must(primitive.ParseDecimal128("-" + decimal))
+}
+
 // Computes and stores some expensive operations. Invoked at the time of model creation.
 func (m *Model[T]) preprocess() {
 	var sample [0]T // Slice of zero length to get the type of T
 	m.docReflectType = reflect.TypeOf(sample).Elem()
+	if m.Schema.Options.SoftDelete {
+		m.EnableSoftDelete(m.Schema.Options.DeletedAtField)
+	}
 }
 
 // Sets the variable that will hold the result of the last executed query.
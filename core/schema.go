@@ -3,12 +3,15 @@ package elemental
 import (
 	"context"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/creasty/defaults"
 	"github.com/elcengine/elemental/utils"
 	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Schema struct {
@@ -44,7 +47,40 @@ func (s Schema) syncIndexes(reflectedBaseType reflect.Type, databaseOverride, co
 	collectionName := lo.CoalesceOrEmpty(collectionOverride, s.Options.Collection)
 	collection := UseDatabase(database, connection).Collection(collectionName)
 	collection.Indexes().DropAll(defaultedCtx)
+	textIndexKeys := bson.D{}
+	textIndexWeights := bson.D{}
 	for field, definition := range s.Definitions {
+		if definition.TextIndex {
+			reflectedField, _ := reflectedBaseType.FieldByName(field)
+			key := cleanTag(reflectedField.Tag.Get("bson"))
+			textIndexKeys = append(textIndexKeys, bson.E{Key: key, Value: "text"})
+			if definition.TextWeight > 0 {
+				textIndexWeights = append(textIndexWeights, bson.E{Key: key, Value: definition.TextWeight})
+			}
+		}
+	}
+	if len(textIndexKeys) > 0 {
+		// Field order is irrelevant for a text index, but a stable order keeps the generated index name consistent across syncs
+		slices.SortFunc(textIndexKeys, func(a, b bson.E) int { return strings.Compare(a.Key, b.Key) })
+		indexOptions := options.Index()
+		if s.Options.TextIndexOptions != nil {
+			indexOptions = lo.ToPtr(*s.Options.TextIndexOptions)
+		}
+		if len(textIndexWeights) > 0 {
+			indexOptions.SetWeights(textIndexWeights)
+		}
+		collection.Indexes().CreateOne(defaultedCtx, mongo.IndexModel{Keys: textIndexKeys, Options: indexOptions})
+	}
+	for field, definition := range s.Definitions {
+		if definition.GeoIndex {
+			reflectedField, _ := reflectedBaseType.FieldByName(field)
+			indexModel := mongo.IndexModel{
+				Keys:    bson.D{{Key: cleanTag(reflectedField.Tag.Get("bson")), Value: "2dsphere"}},
+				Options: definition.Index,
+			}
+			collection.Indexes().CreateOne(defaultedCtx, indexModel)
+			continue
+		}
 		if definition.Index != nil {
 			reflectedField, _ := reflectedBaseType.FieldByName(field)
 			indexModel := mongo.IndexModel{
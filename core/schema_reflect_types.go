@@ -54,3 +54,6 @@ var Float32Slice = reflect.TypeOf([]float32{})
 var Float64Slice = reflect.TypeOf([]float64{})
 var Float32Map = reflect.TypeOf(map[string]float32{})
 var Float64Map = reflect.TypeOf(map[string]float64{})
+var Point = reflect.TypeOf(GeoPoint{})
+var Polygon = reflect.TypeOf(GeoPolygon{})
+var LineString = reflect.TypeOf(GeoLineString{})
@@ -4,6 +4,9 @@ import (
 	"regexp"
 
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type SchemaOptions struct {
@@ -13,6 +16,45 @@ type SchemaOptions struct {
 	Connection              string                          // Custom connection alias, if not set, the default connection will be used
 	Auditing                bool                            // Whether to enable auditing for this model
 	BypassSchemaEnforcement bool                            // Whether to bypass schema enforcement when creating a new document
+	TextIndexOptions        *options.IndexOptions           // Raw driver index options for the text index built from all fields with TextIndex set. Can be used to set the default language, name, etc.
+	ReadPreference          *readpref.ReadPref              // Default read preference for queries of this model, if not set, the read preference of the client will be used
+	ReadConcern             *readconcern.ReadConcern        // Default read concern for queries of this model, if not set, the read concern of the client will be used
+	WriteConcern            *writeconcern.WriteConcern      // Default write concern for writes of this model, if not set, the write concern of the client will be used
+	Virtuals                map[string]Virtual              // Virtual relations keyed by the name of the field they are populated into. These are not stored in the document
+	Tenancy                 *Tenancy                        // Isolates the documents of this model by the tenant within the context of each query. Queries without a tenant fail
+	SoftDelete              bool                            // Whether to enable soft delete for this model, which also hides soft deleted documents when this model is populated
+	DeletedAtField          string                          // The bson name of the field which marks a document as soft deleted. Defaults to deleted_at
+}
+
+type TenancyMode string
+
+const (
+	TenancyModeField    TenancyMode = "field"    // Documents of all tenants share a collection and carry the tenant in a field
+	TenancyModeDatabase TenancyMode = "database" // Documents of each tenant are stored in a database of their own
+)
+
+type Tenancy struct {
+	Mode     TenancyMode             // How documents are isolated. Defaults to TenancyModeField
+	Field    string                  // The bson name of the field which holds the tenant in field mode. Defaults to DefaultTenantField
+	Database func(tenant any) string // Resolves the database of a tenant in database mode. Defaults to the tenant itself
+}
+
+type OnDeleteAction string
+
+const (
+	NoAction OnDeleteAction = ""         // The reference is left as is, even though it no longer resolves
+	Cascade  OnDeleteAction = "cascade"  // Documents holding the reference are deleted along with the referenced document
+	SetNull  OnDeleteAction = "set_null" // The reference is set to null, or pulled from the array if the field is a slice
+	Restrict OnDeleteAction = "restrict" // The delete fails with ErrDeleteRestricted while any document holds the reference
+)
+
+type Virtual struct {
+	Ref          string // Reference to the model which holds the related documents
+	Collection   string // Collection name of the related documents if a Ref is not set
+	LocalField   string // Field of this model which is matched against the foreign field. Defaults to _id
+	ForeignField string // Field of the related documents which references this model
+	JustOne      bool   // Whether to populate only the first related document instead of an array of them
+	Count        bool   // Whether to populate only the number of related documents instead of the documents themselves
 }
 
 type Field struct {
@@ -26,7 +68,11 @@ type Field struct {
 	Regex      *regexp.Regexp        // A regex pattern that the field must match when it is a string
 	Index      *options.IndexOptions // Raw driver index options for the field. Can be used to create unique indexes, sparse indexes, etc.
 	IndexOrder int                   // Sort order for the index. 1 for ascending, -1 for descending
+	TextIndex  bool                  // Whether to include the field in the text index of the collection. All such fields are combined into a single text index
+	TextWeight int32                 // Relative significance of the field within the text index compared to other indexed fields. Defaults to 1
+	GeoIndex   bool                  // Whether to create a 2dsphere index on the field. Required for queries such as Near and GeoNear
 	Ref        string                // Reference to another model if the field is a reference
+	OnDelete   OnDeleteAction        // What happens to this field when the document it references is deleted. Defaults to NoAction
 	Collection string                // Collection name if the field is a reference
 	IsRefID    bool                  // In development for cluster mode, don't use it yet
 }
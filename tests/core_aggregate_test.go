@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCoreAggregate(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	SoftDeleteUserModel := UserModel.SetCollection("soft_deleted_users")
+
+	SoftDeleteUserModel.EnableSoftDelete()
+
+	SoftDeleteUserModel.InsertMany(mocks.Users).Exec()
+
+	type OccupationStats struct {
+		Occupation string `bson:"_id"`
+		Count      int    `bson:"count"`
+		TotalAge   int    `bson:"total_age"`
+	}
+
+	Convey("Aggregate users", t, func() {
+		Convey("Group by occupation", func() {
+			stats := elemental.Aggregate[OccupationStats](UserModel.Where("occupation").Exists(true).
+				Group("$occupation", primitive.M{"count": primitive.M{"$sum": 1}}, primitive.M{"total_age": primitive.M{"$sum": "$age"}}).
+				Sort("_id", 1))
+			So(stats, ShouldResemble, []OccupationStats{
+				{Occupation: "General", Count: 1, TotalAge: mocks.Imlerith.Age},
+				{Occupation: "Mage", Count: 2, TotalAge: mocks.Caranthir.Age + mocks.Yennefer.Age},
+				{Occupation: "Witcher", Count: 2, TotalAge: mocks.Geralt.Age + mocks.Vesemir.Age},
+			})
+		})
+		Convey("Unwind weapons", func() {
+			type Weapon struct {
+				Name   string `bson:"name"`
+				Weapon string `bson:"weapons"`
+			}
+			weapons := elemental.Aggregate[Weapon](UserModel.Find(primitive.M{"name": mocks.Geralt.Name}).Unwind("weapons"))
+			So(weapons, ShouldHaveLength, len(mocks.Geralt.Weapons))
+			So(weapons[0].Weapon, ShouldEqual, mocks.Geralt.Weapons[0])
+			Convey("Preserving documents without weapons", func() {
+				weapons := elemental.Aggregate[Weapon](UserModel.Find(primitive.M{"name": mocks.Ciri.Name}).Unwind("$weapons", true))
+				So(weapons, ShouldHaveLength, 1)
+			})
+		})
+		Convey("Add fields", func() {
+			type AgedUser struct {
+				Name       string `bson:"name"`
+				AgeInDays  int    `bson:"age_in_days"`
+				IsAncestor bool   `bson:"is_ancestor"`
+			}
+			users := elemental.Aggregate[AgedUser](UserModel.Find(primitive.M{"name": mocks.Vesemir.Name}).AddFields(primitive.M{
+				"age_in_days": primitive.M{"$multiply": []any{"$age", 365}},
+				"is_ancestor": primitive.M{"$gt": []any{"$age", 200}},
+			}))
+			So(users, ShouldHaveLength, 1)
+			So(users[0].AgeInDays, ShouldEqual, mocks.Vesemir.Age*365)
+			So(users[0].IsAncestor, ShouldBeTrue)
+		})
+		Convey("Bucket by age", func() {
+			type AgeBucket struct {
+				Min   any `bson:"_id"`
+				Count int `bson:"count"`
+			}
+			buckets := elemental.Aggregate[AgeBucket](UserModel.Bucket("$age", []any{0, 100, 200}, elemental.BucketOptions{
+				Default: "Ancient",
+			}))
+			So(buckets, ShouldHaveLength, 3)
+			So(buckets[0].Count, ShouldEqual, 2)
+			So(buckets[1].Count, ShouldEqual, 4)
+			So(buckets[2].Min, ShouldEqual, "Ancient")
+			So(buckets[2].Count, ShouldEqual, 1)
+			Convey("Automatically", func() {
+				buckets := elemental.Aggregate[AgeBucket](UserModel.BucketAuto("$age", 2))
+				So(buckets, ShouldHaveLength, 2)
+			})
+		})
+		Convey("Facet", func() {
+			type Facets struct {
+				Witchers []User `bson:"witchers"`
+				Mages    []User `bson:"mages"`
+			}
+			facets := elemental.Aggregate[Facets](UserModel.Facet(map[string]mongo.Pipeline{
+				"witchers": {bson.D{{Key: "$match", Value: primitive.M{"occupation": "Witcher"}}}},
+				"mages":    {bson.D{{Key: "$match", Value: primitive.M{"occupation": "Mage"}}}},
+			}))
+			So(facets, ShouldHaveLength, 1)
+			So(facets[0].Witchers, ShouldHaveLength, 2)
+			So(facets[0].Mages, ShouldHaveLength, 2)
+		})
+		Convey("Replace root", func() {
+			type School struct {
+				Name string `bson:"name"`
+			}
+			schools := elemental.Aggregate[School](UserModel.Find(primitive.M{"name": mocks.Geralt.Name}).
+				AddFields(primitive.M{"school": primitive.M{"name": "$school"}}).ReplaceRoot("school"))
+			So(schools, ShouldResemble, []School{{Name: *mocks.Geralt.School}})
+		})
+		Convey("Union with another collection", func() {
+			KingdomModel := KingdomModel.SetDatabase(t.Name())
+			KingdomModel.Create(Kingdom{Name: "Temeria"}).Exec()
+			type Named struct {
+				Name string `bson:"name"`
+			}
+			results := elemental.Aggregate[Named](UserModel.UnionWith(KingdomModel.Schema.Options.Collection,
+				bson.D{{Key: "$project", Value: primitive.M{"name": 1}}}))
+			So(results, ShouldHaveLength, len(mocks.Users)+1)
+			So(results[len(results)-1].Name, ShouldEqual, "Temeria")
+		})
+		Convey("Set window fields", func() {
+			type RankedUser struct {
+				Name string `bson:"name"`
+				Rank int    `bson:"rank"`
+			}
+			users := elemental.Aggregate[RankedUser](UserModel.SetWindowFields(nil, bson.D{{Key: "age", Value: -1}}, primitive.M{
+				"rank": primitive.M{"$rank": primitive.M{}},
+			}))
+			So(users, ShouldHaveLength, len(mocks.Users))
+			So(users[0].Name, ShouldEqual, mocks.Vesemir.Name)
+			So(users[0].Rank, ShouldEqual, 1)
+		})
+		Convey("Sample", func() {
+			So(UserModel.Sample(3).ExecTT(), ShouldHaveLength, 3)
+		})
+		Convey("Out to another collection", func() {
+			UserModel.Find(primitive.M{"occupation": "Witcher"}).Out("witchers").Exec()
+			So(UserModel.SetCollection("witchers").Find().ExecTT(), ShouldHaveLength, 2)
+			Convey("Merge into another collection", func() {
+				UserModel.Find(primitive.M{"occupation": "Mage"}).Merge("witchers", elemental.MergeOptions{
+					WhenMatched:    "replace",
+					WhenNotMatched: "insert",
+				}).Exec()
+				So(UserModel.SetCollection("witchers").Find().ExecTT(), ShouldHaveLength, 4)
+			})
+		})
+		Convey("Raw stages decoded into a custom type", func() {
+			type OccupationCount struct {
+				Occupation string `bson:"_id"`
+				Count      int    `bson:"count"`
+			}
+			counts := elemental.AggregateInto[[]OccupationCount](UserModel.Where("occupation").Exists(true).
+				Stage(bson.D{{Key: "$sortByCount", Value: "$occupation"}}, bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}}))
+			So(counts, ShouldResemble, []OccupationCount{
+				{Occupation: "Mage", Count: 2},
+				{Occupation: "Witcher", Count: 2},
+				{Occupation: "General", Count: 1},
+			})
+		})
+		Convey("Single document decoded into a custom type", func() {
+			type AgeRange struct {
+				Youngest int `bson:"youngest"`
+				Oldest   int `bson:"oldest"`
+			}
+			ageRange := elemental.AggregateInto[AgeRange](UserModel.Group(nil, primitive.M{
+				"youngest": primitive.M{"$min": "$age"},
+				"oldest":   primitive.M{"$max": "$age"},
+			}))
+			So(ageRange.Oldest, ShouldEqual, mocks.Vesemir.Age)
+			So(ageRange.Youngest, ShouldBeLessThan, ageRange.Oldest)
+			So(func() {
+				elemental.AggregateInto[AgeRange](UserModel.Stage(bson.D{{Key: "$match", Value: primitive.M{"age": -1}}}).OrFail())
+			}, ShouldPanic)
+		})
+		Convey("Raw stages on a model with soft delete enabled", func() {
+			SoftDeleteUserModel.DeleteOne(primitive.M{"name": mocks.Geralt.Name}).Exec()
+			users := elemental.Aggregate[User](SoftDeleteUserModel.Stage(bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}}))
+			So(users, ShouldHaveLength, len(mocks.Users)-1)
+			So(lo.ContainsBy(users, func(u User) bool { return u.Name == mocks.Geralt.Name }), ShouldBeFalse)
+		})
+	})
+}
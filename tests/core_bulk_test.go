@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCoreBulk(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	UserModel.SyncIndexes()
+
+	Convey("Bulk write users", t, func() {
+		Convey("Mixed operations", func() {
+			result := UserModel.Bulk().
+				InsertOne(User{Name: "Regis", Age: 400}).
+				InsertOne(User{Name: "Dettlaff", Age: 500}).
+				UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Vintner"}).
+				UpdateMany(&primitive.M{"occupation": "Mage"}, primitive.M{"retired": true}).
+				ReplaceOne(&primitive.M{"name": mocks.Imlerith.Name}, User{Name: mocks.Imlerith.Name, Age: 151}).
+				DeleteOne(primitive.M{"name": "Dettlaff"}).
+				DeleteMany(primitive.M{"name": primitive.M{"$in": []string{mocks.Eredin.Name, mocks.Caranthir.Name}}}).
+				Exec()
+			So(result.Errors, ShouldBeEmpty)
+			So(result.InsertedCount, ShouldEqual, 2)
+			So(result.MatchedCount, ShouldEqual, 4)
+			So(result.DeletedCount, ShouldEqual, 3)
+			So(result.InsertedIDs, ShouldHaveLength, 2)
+			So(result.InsertedIDs[0], ShouldHaveSameTypeAs, primitive.ObjectID{})
+			So(UserModel.FindOne(primitive.M{"name": "Regis"}).ExecPtr(), ShouldNotBeNil)
+			So(UserModel.FindOne(primitive.M{"name": "Dettlaff"}).ExecPtr(), ShouldBeNil)
+			So(UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT().Occupation, ShouldEqual, "Vintner")
+			So(UserModel.FindOne(primitive.M{"name": mocks.Imlerith.Name}).ExecT().Age, ShouldEqual, 151)
+			So(UserModel.FindOne(primitive.M{"name": mocks.Yennefer.Name}).ExecT().Retired, ShouldBeTrue)
+		})
+		Convey("Operations which fail validation in unordered mode", func() {
+			result := UserModel.Bulk().Unordered().
+				InsertOne(User{Name: "Emiel"}).
+				InsertOne(User{Age: 20}).
+				InsertOne(User{Name: "Orianna"}).
+				Exec()
+			So(result.Errors, ShouldHaveLength, 1)
+			So(result.Errors[1], ShouldNotBeNil)
+			So(result.InsertedCount, ShouldEqual, 2)
+			So(result.InsertedIDs, ShouldContainKey, 0)
+			So(result.InsertedIDs, ShouldContainKey, 2)
+		})
+		Convey("Operations which are rejected by the server in ordered mode", func() {
+			result := UserModel.Bulk().
+				InsertOne(User{Name: "Syanna"}).
+				InsertOne(User{Name: mocks.Ciri.Name}).
+				InsertOne(User{Name: "Anna Henrietta"}).
+				Exec()
+			So(result.Errors, ShouldHaveLength, 1)
+			So(mongo.IsDuplicateKeyError(result.Errors[1]), ShouldBeTrue)
+			So(result.InsertedCount, ShouldEqual, 1)
+			So(result.InsertedIDs, ShouldHaveLength, 1)
+			So(result.InsertedIDs, ShouldContainKey, 0)
+			So(UserModel.FindOne(primitive.M{"name": "Anna Henrietta"}).ExecPtr(), ShouldBeNil)
+		})
+		Convey("Operations which are rejected by the server in unordered mode", func() {
+			result := UserModel.Bulk().Unordered().
+				InsertOne(User{Name: mocks.Ciri.Name}).
+				InsertOne(User{Name: "Anna Henrietta"}).
+				Exec()
+			So(result.Errors, ShouldContainKey, 0)
+			So(result.InsertedIDs, ShouldContainKey, 1)
+		})
+	})
+
+	Convey("Run the middleware of each operation", t, func() {
+		type Keep struct {
+			ID   primitive.ObjectID `json:"_id" bson:"_id"`
+			Name string             `json:"name" bson:"name"`
+		}
+
+		hooks := func(model elemental.Model[Keep]) map[string]int {
+			invokedHooks := make(map[string]int)
+			model.PreUpdateOne(func(doc any) bool {
+				invokedHooks["preUpdateOne"]++
+				return true
+			})
+			model.PostUpdateOne(func(result *mongo.UpdateResult, err error) bool {
+				invokedHooks["postUpdateOne"]++
+				return true
+			})
+			model.PreUpdateMany(func(doc any) bool {
+				invokedHooks["preUpdateMany"]++
+				return true
+			})
+			model.PostUpdateMany(func(result *mongo.UpdateResult, err error) bool {
+				invokedHooks["postUpdateMany"]++
+				return true
+			})
+			model.PreDeleteOne(func(filters *primitive.M) bool {
+				invokedHooks["preDeleteOne"]++
+				return true
+			})
+			model.PostDeleteOne(func(result *mongo.DeleteResult, err error) bool {
+				invokedHooks["postDeleteOne"]++
+				return true
+			})
+			model.PreDeleteMany(func(filters *primitive.M) bool {
+				invokedHooks["preDeleteMany"]++
+				return true
+			})
+			model.PostDeleteMany(func(result *mongo.DeleteResult, err error) bool {
+				invokedHooks["postDeleteMany"]++
+				return true
+			})
+			return invokedHooks
+		}
+
+		write := func(model elemental.Model[Keep]) {
+			model.InsertMany([]Keep{{Name: "Kaer Morhen"}, {Name: "Kaer Trolde"}, {Name: "Stygga"}}).Exec()
+			result := model.Bulk().
+				UpdateOne(&primitive.M{"name": "Kaer Morhen"}, primitive.M{"name": "Kaer Morhen Ruins"}).
+				UpdateMany(&primitive.M{"name": "Kaer Trolde"}, primitive.M{"name": "Kaer Trolde Harbour"}).
+				DeleteOne(primitive.M{"name": "Stygga"}).
+				DeleteMany(primitive.M{"name": primitive.M{"$in": []string{"Kaer Morhen Ruins", "Kaer Trolde Harbour"}}}).
+				Exec()
+			So(result.Errors, ShouldBeEmpty)
+			So(model.CountDocuments().Exec(), ShouldEqual, 0)
+		}
+
+		Convey("Hard deletes", func() {
+			KeepModel := elemental.NewModel[Keep]("Keep-For-Bulk-Middleware", elemental.NewSchema(map[string]elemental.Field{
+				"Name": {
+					Type: elemental.String,
+				},
+			})).SetDatabase(t.Name())
+			invokedHooks := hooks(KeepModel)
+			write(KeepModel)
+			So(invokedHooks, ShouldResemble, map[string]int{
+				"preUpdateOne": 1, "postUpdateOne": 1,
+				"preUpdateMany": 1, "postUpdateMany": 1,
+				"preDeleteOne": 1, "postDeleteOne": 1,
+				"preDeleteMany": 1, "postDeleteMany": 1,
+			})
+		})
+
+		Convey("Soft deletes", func() {
+			KeepModel := elemental.NewModel[Keep]("Soft-Deleted-Keep-For-Bulk-Middleware", elemental.NewSchema(map[string]elemental.Field{
+				"Name": {
+					Type: elemental.String,
+				},
+			}, elemental.SchemaOptions{
+				SoftDelete: true,
+			})).SetDatabase(t.Name())
+			invokedHooks := hooks(KeepModel)
+			write(KeepModel)
+			So(invokedHooks, ShouldResemble, map[string]int{
+				"preUpdateOne": 2, "postUpdateOne": 2,
+				"preUpdateMany": 2, "postUpdateMany": 2,
+			})
+		})
+	})
+}
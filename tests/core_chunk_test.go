@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreChunk(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	collect := func() (func(users []User) error, func() []string) {
+		var mu sync.Mutex
+		var names []string
+		return func(users []User) error {
+				mu.Lock()
+				defer mu.Unlock()
+				names = append(names, lo.Map(users, func(u User, _ int) string { return u.Name })...)
+				return nil
+			}, func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return names
+			}
+	}
+
+	allNames := lo.Map(mocks.Users, func(u User, _ int) string { return u.Name })
+
+	Convey("Process users in chunks", t, func() {
+		Convey("Walk through all users with skip and limit", func() {
+			fn, names := collect()
+			result := UserModel.Chunk(3, fn).Exec().(elemental.ChunkResult)
+			So(result.Batches, ShouldEqual, 3)
+			So(result.Documents, ShouldEqual, len(mocks.Users))
+			So(names(), ShouldResemble, allNames)
+		})
+		Convey("Walk through filtered users in the order of the query", func() {
+			fn, names := collect()
+			UserModel.Where("age").GreaterThan(100).Sort("age", -1).Chunk(2, fn).Exec()
+			So(names(), ShouldResemble, []string{mocks.Vesemir.Name, mocks.Imlerith.Name, mocks.Caranthir.Name})
+		})
+		Convey("Walk through all users by id with multiple workers", func() {
+			fn, names := collect()
+			result := UserModel.ChunkByID(2, fn, elemental.ChunkOptions{Workers: 3}).Exec().(elemental.ChunkResult)
+			So(result.Batches, ShouldEqual, 4)
+			So(names(), ShouldHaveLength, len(mocks.Users))
+			So(lo.Uniq(names()), ShouldHaveLength, len(mocks.Users))
+		})
+		Convey("Skip soft deleted users", func() {
+			SoftDeleteUserModel := UserModel.SetCollection("soft_deleted_chunk_users")
+			SoftDeleteUserModel.InsertMany(mocks.Users).Exec()
+			SoftDeleteUserModel.EnableSoftDelete()
+			SoftDeleteUserModel.DeleteOne(primitive.M{"name": mocks.Eredin.Name}).Exec()
+			fn, names := collect()
+			SoftDeleteUserModel.ChunkByID(4, fn).Exec()
+			So(names(), ShouldHaveLength, len(mocks.Users)-1)
+			So(names(), ShouldNotContain, mocks.Eredin.Name)
+		})
+		Convey("Stop at the first error", func() {
+			errStop := errors.New("stop")
+			batches := 0
+			So(func() {
+				UserModel.ChunkByID(2, func(users []User) error {
+					batches++
+					if batches == 2 {
+						return errStop
+					}
+					return nil
+				}).Exec()
+			}, ShouldPanicWith, errStop)
+			So(batches, ShouldEqual, 2)
+		})
+		Convey("Resume an interrupted run from its checkpoint", func() {
+			opts := elemental.ChunkOptions{Checkpoint: "chunk-users"}
+			var processed []string
+			So(func() {
+				UserModel.ChunkByID(2, func(users []User) error {
+					if len(processed) == 4 {
+						return errors.New("interrupted")
+					}
+					processed = append(processed, lo.Map(users, func(u User, _ int) string { return u.Name })...)
+					return nil
+				}, opts).Exec()
+			}, ShouldPanic)
+			So(processed, ShouldHaveLength, 4)
+			checkpoints := UserModel.Collection().Database().Collection(elemental.DefaultCheckpointCollection)
+			So(lo.Must(checkpoints.CountDocuments(context.Background(), primitive.M{"_id": "users:chunk-users"})), ShouldEqual, 1)
+			fn, names := collect()
+			result := UserModel.ChunkByID(2, fn, opts).Exec().(elemental.ChunkResult)
+			So(result.Documents, ShouldEqual, len(mocks.Users)-4)
+			So(append(processed, names()...), ShouldResemble, allNames)
+			So(lo.Must(checkpoints.CountDocuments(context.Background(), primitive.M{"_id": "users:chunk-users"})), ShouldEqual, 0)
+		})
+		Convey("Reject a chunk size which is not positive", func() {
+			So(func() {
+				UserModel.Chunk(0, func(users []User) error { return nil }).Exec()
+			}, ShouldPanicWith, elemental.ErrInvalidChunkSize)
+		})
+	})
+}
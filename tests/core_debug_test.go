@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreDebug(t *testing.T) { // Not parallel since the query logger is shared by all models
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	Convey("Inspect queries", t, func() {
+		query := UserModel.Where("age").GreaterThan(100).Sort("name", 1)
+		Convey("Pipeline", func() {
+			pipeline := query.Pipeline()
+			So(pipeline, ShouldHaveLength, 2)
+			So(pipeline[0][0].Key, ShouldEqual, "$match")
+			So(pipeline[1][0].Key, ShouldEqual, "$sort")
+			pipeline[1][0].Key = "$project"
+			So(query.Pipeline()[1][0].Key, ShouldEqual, "$sort")
+		})
+		Convey("Canonical extended JSON", func() {
+			So(query.String(), ShouldEqual, `[{"$match":{"age":{"$gt":{"$numberInt":"100"}}}},{"$sort":{"name":{"$numberInt":"1"}}}]`)
+			So(UserModel.Find(primitive.M{"name": "Geralt", "age": 100}).String(), ShouldEqual,
+				UserModel.Find(primitive.M{"age": 100, "name": "Geralt"}).String())
+		})
+		Convey("Mongo shell command", func() {
+			So(query.ToShell(), ShouldEqual, `db.users.aggregate([{ $match: { age: { $gt: 100 } } }, { $sort: { name: 1 } }])`)
+			id := primitive.NewObjectID()
+			So(UserModel.FindByID(id).Hint("_id_").ToShell(), ShouldEqual,
+				`db.users.aggregate([{ $match: { _id: ObjectId("`+id.Hex()+`") } }, { $limit: 1 }], { hint: "_id_" })`)
+			So(UserModel.SetCollection("retired-users").Find(primitive.M{"name": primitive.Regex{Pattern: "^ger", Options: "i"}}).ToShell(),
+				ShouldEqual, `db.getCollection("retired-users").aggregate([{ $match: { name: /^ger/i } }])`)
+		})
+		Convey("Keys which are not identifiers", func() {
+			So(UserModel.Find(primitive.M{"weapons.0": bson.M{"$exists": true}}).ToShell(), ShouldEqual,
+				`db.users.aggregate([{ $match: { "weapons.0": { $exists: true } } }])`)
+		})
+		Convey("Populated references", func() {
+			shell := BestiaryModel.Find().Populate("monster").ToShell()
+			So(shell, ShouldContainSubstring, `{ $lookup: { as: "monster", foreignField: "_id", from: "monsters", localField: "monster" } }`)
+			So(shell, ShouldNotContainSubstring, "pipeline")
+		})
+	})
+
+	Convey("Log executed queries", t, func() {
+		var mu sync.Mutex
+		var entries []elemental.QueryLogEntry
+		elemental.LogQueries(func(entry elemental.QueryLogEntry) {
+			if entry.Database != t.Name() {
+				return // Ignore the queries of other tests which are still running in the background
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			entries = append(entries, entry)
+		})
+		Reset(elemental.DisableQueryLogging)
+		UserModel.Where("name", mocks.Geralt.Name).Exec()
+		UserModel.UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Vintner"}).Exec()
+		So(func() {
+			UserModel.Find().Hint("non_existent_index").Exec()
+		}, ShouldPanic)
+		ActiveUserModel := elemental.NewModel[User]("User-With-Logged-Scope", elemental.NewSchema(map[string]elemental.Field{}, elemental.SchemaOptions{
+			Collection: "users",
+		})).SetDatabase(t.Name()).AddGlobalScope("active", func(m elemental.Model[User]) elemental.Model[User] {
+			return m.Where("retired", false)
+		})
+		ActiveUserModel.Find().Exec()
+		So(entries, ShouldHaveLength, 4)
+		So(entries[0].Model, ShouldEqual, UserModel.Name)
+		So(entries[0].Collection, ShouldEqual, "users")
+		So(entries[0].Operation, ShouldEqual, "Exec")
+		So(entries[0].Shell, ShouldContainSubstring, mocks.Geralt.Name)
+		So(entries[0].Duration, ShouldBeGreaterThan, 0)
+		So(entries[0].Error, ShouldBeNil)
+		So(entries[1].Operation, ShouldEqual, "UpdateOne")
+		So(entries[1].Pipeline, ShouldBeEmpty)
+		So(entries[1].Shell, ShouldBeEmpty)
+		So(entries[2].Operation, ShouldEqual, "Find")
+		So(entries[2].Error, ShouldNotBeNil)
+		So(entries[3].Shell, ShouldEqual, `db.users.aggregate([{ $match: { retired: false } }])`)
+		So(entries[3].Pipeline, ShouldHaveLength, 1)
+	})
+}
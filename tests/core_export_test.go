@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreExportImport(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	Convey("Export users", t, func() {
+		Convey("As JSON Lines", func() {
+			var buffer bytes.Buffer
+			count := UserModel.Where("age").GreaterThan(100).Export(&buffer, elemental.FormatJSONL).Exec()
+			So(count, ShouldEqual, 3)
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			So(lines, ShouldHaveLength, 3)
+			var user User
+			So(json.Unmarshal([]byte(lines[0]), &user), ShouldBeNil)
+			So(user.Name, ShouldEqual, mocks.Caranthir.Name)
+		})
+		Convey("As CSV", func() {
+			var buffer bytes.Buffer
+			UserModel.Find().Export(&buffer, elemental.FormatCSV).Exec()
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			So(lines, ShouldHaveLength, len(mocks.Users)+1)
+			So(lines[0], ShouldEqual, "_id,name,age,occupation,weapons,retired,school,created_at,updated_at")
+			So(lines[2], ShouldContainSubstring, `"[""Silver sword"",""Mahakaman battle hammer"",`)
+		})
+		Convey("As CSV with the header only if nothing matches", func() {
+			var buffer bytes.Buffer
+			UserModel.Where("age").GreaterThan(10000).Export(&buffer, elemental.FormatCSV).Exec()
+			So(strings.TrimSpace(buffer.String()), ShouldEqual, "_id,name,age,occupation,weapons,retired,school,created_at,updated_at")
+		})
+		Convey("As CSV with the fields of every document of a map model", func() {
+			BestiaryModel := elemental.NewModel[bson.M]("Bestiary-Entry-For-Export", elemental.NewSchema(map[string]elemental.Field{}, elemental.SchemaOptions{
+				Collection: "bestiary_entries",
+			})).SetDatabase(t.Name())
+			lo.Must(BestiaryModel.Collection().InsertMany(context.Background(), []any{
+				bson.M{"name": "Leshen", "class": "Relict"},
+				bson.M{"name": "Nekker", "weakness": "Dancing star"},
+			}))
+			var buffer bytes.Buffer
+			BestiaryModel.Find().Export(&buffer, elemental.FormatCSV).Exec()
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			So(lines, ShouldHaveLength, 3)
+			So(lines[0], ShouldEqual, "_id,class,name,weakness")
+			So(lines[2], ShouldEndWith, ",,Nekker,Dancing star")
+		})
+		Convey("As Extended JSON", func() {
+			var buffer bytes.Buffer
+			UserModel.Find(primitive.M{"name": mocks.Geralt.Name}).Export(&buffer, elemental.FormatExtendedJSON).Exec()
+			So(buffer.String(), ShouldContainSubstring, `"_id":{"$oid":`)
+			So(buffer.String(), ShouldContainSubstring, `"age":{"$numberLong":"100"}`)
+		})
+		Convey("In an unsupported format", func() {
+			So(func() {
+				UserModel.Export(&bytes.Buffer{}, "xml").Exec()
+			}, ShouldPanicWith, elemental.ErrUnsupportedFormat)
+		})
+	})
+
+	Convey("Import users", t, func() {
+		for _, format := range []elemental.DataFormat{elemental.FormatJSONL, elemental.FormatCSV, elemental.FormatExtendedJSON} {
+			Convey("Exported as "+string(format), func() {
+				var buffer bytes.Buffer
+				UserModel.Export(&buffer, format).Exec()
+				ImportedUserModel := UserModel.SetCollection("imported_users_" + string(format))
+				result := ImportedUserModel.Import(&buffer, format, elemental.ImportOptions{BatchSize: 2}).Exec().(elemental.ImportResult)
+				So(result.Failed, ShouldBeEmpty)
+				So(result.Inserted, ShouldEqual, len(mocks.Users))
+				original := UserModel.FindOne(primitive.M{"name": mocks.Vesemir.Name}).ExecT()
+				imported := ImportedUserModel.FindByID(original.ID).ExecT()
+				So(imported.Name, ShouldEqual, original.Name)
+				So(imported.Age, ShouldEqual, original.Age)
+				So(imported.Weapons, ShouldResemble, original.Weapons)
+				So(lo.FromPtr(imported.School), ShouldEqual, lo.FromPtr(original.School))
+				So(imported.CreatedAt.Equal(original.CreatedAt), ShouldBeTrue)
+			})
+		}
+		Convey("Reporting the rows which failed", func() {
+			input := strings.Join([]string{
+				`{"name": "Lambert", "age": 90}`,
+				`{"name": "Eskel"`,
+				``,
+				`{"age": 5}`,
+				`{"name": "Coën"}`,
+			}, "\n")
+			result := UserModel.SetCollection("imported_users_with_errors").Import(strings.NewReader(input), elemental.FormatJSONL).Exec().(elemental.ImportResult)
+			So(result.Inserted, ShouldEqual, 2)
+			So(lo.Map(result.Failed, func(e elemental.ImportError, _ int) int { return e.Row }), ShouldResemble, []int{2, 3})
+			So(result.Failed[1].Error(), ShouldEqual, "row 3: field Name is required")
+			coen := UserModel.SetCollection("imported_users_with_errors").FindOne(primitive.M{"name": "Coën"}).ExecT()
+			So(coen.Age, ShouldEqual, fixtures.DefaultUserAge)
+		})
+		Convey("Stopping at the first row which failed", func() {
+			input := "name,age\nLambert,90\nEskel,old\nCoën,50\n"
+			result := UserModel.SetCollection("imported_users_until_error").Import(strings.NewReader(input), elemental.FormatCSV, elemental.ImportOptions{StopOnError: true}).Exec().(elemental.ImportResult)
+			So(result.Inserted, ShouldEqual, 1)
+			So(result.Failed, ShouldHaveLength, 1)
+			So(result.Failed[0].Row, ShouldEqual, 2)
+		})
+	})
+}
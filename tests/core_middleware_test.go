@@ -210,4 +210,46 @@ func TestCoreMiddleware(t *testing.T) {
 			So(replacedCastle.Name, ShouldEqual, "Replaced: Drakenborg")
 		})
 	})
+
+	Convey("Hooks of chained update operators", t, func() {
+		chainedHooks := make(map[string]int)
+
+		TowerModel := elemental.NewModel[Castle]("Tower-For-Middleware", elemental.NewSchema(map[string]elemental.Field{
+			"Name": {
+				Type: elemental.String,
+			},
+		})).SetDatabase(t.Name())
+
+		TowerModel.PreUpdateOne(func(doc any) bool {
+			chainedHooks["preUpdateOne"]++
+			return true
+		})
+
+		TowerModel.PostUpdateOne(func(result *mongo.UpdateResult, err error) bool {
+			chainedHooks["postUpdateOne"]++
+			return true
+		})
+
+		TowerModel.PreFindOneAndUpdate(func(filter *primitive.M, doc any) bool {
+			chainedHooks["preFindOneAndUpdate"]++
+			return true
+		})
+
+		TowerModel.PostFindOneAndUpdate(func(castle *Castle) bool {
+			chainedHooks["postFindOneAndUpdate"]++
+			return true
+		})
+
+		TowerModel.Create(Castle{Name: "Tower of the Swallow"}).Exec()
+
+		TowerModel.Where("name", "Tower of the Swallow").Set(primitive.M{"name": "Tower of the Gull"}).One().Exec()
+
+		tower := TowerModel.Where("name", "Tower of the Gull").Set(primitive.M{"name": "Tor Zireael"}).FindOneAndModify().New().ExecT()
+
+		So(chainedHooks["preUpdateOne"], ShouldEqual, 1)
+		So(chainedHooks["postUpdateOne"], ShouldEqual, 1)
+		So(chainedHooks["preFindOneAndUpdate"], ShouldEqual, 1)
+		So(chainedHooks["postFindOneAndUpdate"], ShouldEqual, 1)
+		So(tower.Name, ShouldEqual, "Tor Zireael")
+	})
 }
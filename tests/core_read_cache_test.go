@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestCoreReadCache(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	// Inserts a document without going through the executors of the model, hence without invalidating the cache
+	insertBehindTheCache := func(user User) {
+		UserModel.Collection().InsertOne(context.Background(), primitive.M{"name": user.Name, "age": user.Age})
+	}
+
+	Convey("Cache query results", t, func() {
+		Convey("Serve repeated queries from the cache", func() {
+			query := UserModel.Find(primitive.M{"age": primitive.M{"$gt": 100}}).Cache(time.Minute)
+			So(query.ExecTT(), ShouldHaveLength, 3)
+			insertBehindTheCache(User{Name: "Regis", Age: 400})
+			So(query.ExecTT(), ShouldHaveLength, 3)
+			So(UserModel.Find(primitive.M{"age": primitive.M{"$gt": 100}}).ExecTT(), ShouldHaveLength, 4)
+			Convey("Invalidate the cache on writes through the model", func() {
+				UserModel.Create(User{Name: "Dettlaff", Age: 500}).Exec()
+				So(query.ExecTT(), ShouldHaveLength, 5)
+			})
+		})
+		Convey("Expire cached results after the ttl", func() {
+			query := UserModel.Where("name", "Emiel").Cache(100 * time.Millisecond)
+			So(query.ExecTT(), ShouldBeEmpty)
+			insertBehindTheCache(User{Name: "Emiel", Age: 300})
+			So(query.ExecTT(), ShouldBeEmpty)
+			time.Sleep(150 * time.Millisecond)
+			So(query.ExecTT(), ShouldHaveLength, 1)
+		})
+		Convey("Cache results separately for each read preference", func() {
+			query := UserModel.Where("name", "Orianna").Cache(time.Minute)
+			So(query.ExecTT(), ShouldBeEmpty)
+			insertBehindTheCache(User{Name: "Orianna", Age: 350})
+			So(query.ExecTT(), ShouldBeEmpty)
+			So(query.ReadFrom(readpref.PrimaryPreferred()).ExecTT(), ShouldHaveLength, 1)
+		})
+		Convey("Invalidate the cache on updates", func() {
+			query := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).Cache(time.Minute)
+			So(query.ExecT().Occupation, ShouldEqual, mocks.Geralt.Occupation)
+			UserModel.UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Vintner"}).Exec()
+			So(query.ExecT().Occupation, ShouldEqual, "Vintner")
+		})
+	})
+
+	Convey("In-memory cache store", t, func() {
+		store := elemental.NewMemoryCache(2)
+		store.Set("a", 1, 0)
+		store.Set("b", 2, 0)
+		store.Get("a")
+		store.Set("c", 3, 0)
+		So(store.Len(), ShouldEqual, 2)
+		_, found := store.Get("b")
+		So(found, ShouldBeFalse)
+		value, found := store.Get("a")
+		So(found, ShouldBeTrue)
+		So(value, ShouldEqual, 1)
+		store.Delete("a")
+		_, found = store.Get("a")
+		So(found, ShouldBeFalse)
+	})
+}
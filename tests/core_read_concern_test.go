@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+func TestCoreReadConcern(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	// The test deployment is a single node replica set, hence there is no secondary to read from or replicate to
+	timeout := func() context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	Convey("Read preference, read concern and write concern", t, func() {
+		Convey("Per query", func() {
+			So(UserModel.Find().ReadFrom(readpref.SecondaryPreferred()).ExecTT(), ShouldHaveLength, len(mocks.Users))
+			So(func() {
+				UserModel.Find().ReadFrom(readpref.Secondary()).Exec(timeout())
+			}, ShouldPanic)
+			So(UserModel.Find().WithReadConcern(readconcern.Majority()).ExecTT(), ShouldHaveLength, len(mocks.Users))
+			So(func() {
+				UserModel.Find().WithReadConcern(&readconcern.ReadConcern{Level: "eventual"}).Exec()
+			}, ShouldPanic)
+			UserModel.WithWriteConcern(writeconcern.Majority()).
+				UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Vintner"}).Exec()
+			So(UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT().Occupation, ShouldEqual, "Vintner")
+			So(func() {
+				UserModel.WithWriteConcern(&writeconcern.WriteConcern{W: 2}).
+					UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Witcher"}).Exec(timeout())
+			}, ShouldPanic)
+		})
+		Convey("Per model", func() {
+			SecondaryUserModel := elemental.NewModel[User]("User-For-Read-Preference", elemental.NewSchema(UserModel.Schema.Definitions, elemental.SchemaOptions{
+				Collection:     "users",
+				ReadPreference: readpref.Secondary(),
+			})).SetDatabase(t.Name())
+			So(func() {
+				SecondaryUserModel.Find().Exec(timeout())
+			}, ShouldPanic)
+			Convey("Overridden per query", func() {
+				So(SecondaryUserModel.Find().ReadFrom(readpref.Primary()).ExecTT(), ShouldHaveLength, len(mocks.Users))
+			})
+		})
+	})
+}
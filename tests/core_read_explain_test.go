@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReadExplain(t *testing.T) {
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	UserModel.SyncIndexes()
+
+	Convey("Explain queries", t, func() {
+		Convey("Query on an unindexed field", func() {
+			result := UserModel.Find(primitive.M{"age": primitive.M{"$gt": 100}}).Explain(elemental.ExplainExecutionStats)
+			So(result.CollectionScan, ShouldBeTrue)
+			So(result.IndexScan, ShouldBeFalse)
+			So(result.Stages, ShouldContain, "COLLSCAN")
+			So(result.IndexesUsed, ShouldBeEmpty)
+			So(result.DocsExamined, ShouldEqual, len(mocks.Users))
+			So(result.Raw, ShouldNotBeEmpty)
+		})
+		Convey("Query on an indexed field", func() {
+			result := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).Explain(elemental.ExplainExecutionStats)
+			So(result.CollectionScan, ShouldBeFalse)
+			So(result.IndexScan, ShouldBeTrue)
+			So(result.IndexesUsed, ShouldContain, "name_1")
+			So(result.KeysExamined, ShouldEqual, 1)
+			So(result.DocsReturned, ShouldEqual, 1)
+		})
+		Convey("Query planner verbosity", func() {
+			result := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).Explain(elemental.ExplainQueryPlanner)
+			So(result.WinningPlan, ShouldNotBeNil)
+			So(result.IndexesUsed, ShouldContain, "name_1")
+			So(result.DocsExamined, ShouldBeZeroValue)
+		})
+	})
+
+	Convey("Collection scan warnings", t, func() {
+		var warnings []string
+		elemental.WarnOnCollectionScans(elemental.CollectionScanWarningOptions{
+			Logger: func(format string, args ...any) {
+				warnings = append(warnings, fmt.Sprintf(format, args...))
+			},
+		})
+		Reset(elemental.DisableCollectionScanWarnings)
+		Convey("Query which scans the collection", func() {
+			UserModel.Find(primitive.M{"age": primitive.M{"$gt": 100}}).Exec()
+			So(warnings, ShouldHaveLength, 1)
+			So(warnings[0], ShouldContainSubstring, UserModel.Collection().Name())
+		})
+		Convey("Query which uses an index", func() {
+			UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).Exec()
+			So(warnings, ShouldBeEmpty)
+		})
+		Convey("Collection smaller than the threshold", func() {
+			elemental.WarnOnCollectionScans(elemental.CollectionScanWarningOptions{
+				Threshold: int64(len(mocks.Users)),
+				Logger: func(format string, args ...any) {
+					warnings = append(warnings, fmt.Sprintf(format, args...))
+				},
+			})
+			UserModel.Find().Exec()
+			So(warnings, ShouldBeEmpty)
+		})
+	})
+}
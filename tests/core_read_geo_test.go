@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReadGeo(t *testing.T) {
+	t.Parallel()
+
+	ts.Connection(t.Name())
+
+	type Store struct {
+		ID       primitive.ObjectID `json:"_id" bson:"_id"`
+		Name     string             `json:"name" bson:"name"`
+		Location elemental.GeoPoint `json:"location" bson:"location"`
+	}
+
+	StoreModel := elemental.NewModel[Store]("Store-For-Geo", elemental.NewSchema(map[string]elemental.Field{
+		"Name": {
+			Type:     elemental.String,
+			Required: true,
+		},
+		"Location": {
+			Type:     elemental.Point,
+			Required: true,
+			GeoIndex: true,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "stores",
+	})).SetDatabase(t.Name())
+
+	StoreModel.SyncIndexes()
+
+	StoreModel.InsertMany([]Store{
+		{Name: "Origin", Location: elemental.NewGeoPoint(0, 0)},
+		{Name: "Next Door", Location: elemental.NewGeoPoint(0, 0.01)},
+		{Name: "Across Town", Location: elemental.NewGeoPoint(0, 0.05)},
+		{Name: "Far Away", Location: elemental.NewGeoPoint(1, 1)},
+	}).Exec()
+
+	origin := elemental.NewGeoPoint(0, 0)
+
+	names := func(stores []Store) []string {
+		return lo.Map(stores, func(s Store, _ int) string { return s.Name })
+	}
+
+	Convey("Query stores by location", t, func() {
+		Convey("Near a point", func() {
+			stores := StoreModel.Where("location").Near(origin, 2000).ExecTT()
+			So(names(stores), ShouldResemble, []string{"Origin", "Next Door"})
+			Convey("With a minimum distance", func() {
+				stores := StoreModel.Where("location").Near(origin, 10000, 1000).ExecTT()
+				So(names(stores), ShouldResemble, []string{"Next Door", "Across Town"})
+			})
+			Convey("In conjunction with other filters", func() {
+				stores := StoreModel.Where("location").Near(origin, 10000).Where("name").NotEquals("Origin").ExecTT()
+				So(names(stores), ShouldResemble, []string{"Next Door", "Across Town"})
+			})
+			Convey("Replacing a previous near condition", func() {
+				query := StoreModel.Where("location").Near(origin, 10000).Where("location").Near(origin, 2000)
+				So(query.Pipeline()[0][0].Key, ShouldEqual, "$geoNear")
+				So(query.Pipeline(), ShouldHaveLength, 2)
+				So(names(query.ExecTT()), ShouldResemble, []string{"Origin", "Next Door"})
+			})
+		})
+		Convey("Polygons leave the given rings untouched", func() {
+			ring := [][]float64{{0, 0}, {1, 0}, {1, 1}}
+			polygon := elemental.NewGeoPolygon(ring)
+			So(ring, ShouldHaveLength, 3)
+			So(polygon.Coordinates[0], ShouldHaveLength, 4)
+		})
+		Convey("Within a polygon", func() {
+			stores := StoreModel.Where("location").GeoWithin(elemental.NewGeoPolygon([][]float64{
+				{-0.02, -0.02}, {0.02, -0.02}, {0.02, 0.02}, {-0.02, 0.02},
+			})).Sort("name", 1).ExecTT()
+			So(names(stores), ShouldResemble, []string{"Next Door", "Origin"})
+		})
+		Convey("Within a circle", func() {
+			stores := StoreModel.Where("location").GeoWithin(elemental.NewGeoCircle(origin, 6000)).ExecTT()
+			So(stores, ShouldHaveLength, 3)
+		})
+		Convey("Intersecting a geometry", func() {
+			stores := StoreModel.Where("location").GeoIntersects(elemental.NewGeoPolygon([][]float64{
+				{0.9, 0.9}, {1.1, 0.9}, {1.1, 1.1}, {0.9, 1.1},
+			})).ExecTT()
+			So(names(stores), ShouldResemble, []string{"Far Away"})
+		})
+		Convey("Geo near stage with the computed distance", func() {
+			type StoreWithDistance struct {
+				Name     string  `bson:"name"`
+				Distance float64 `bson:"distance"`
+			}
+			stores := elemental.Aggregate[StoreWithDistance](StoreModel.GeoNear(origin, "distance", elemental.GeoNearOptions{
+				MaxDistance:        10000,
+				DistanceMultiplier: 0.001,
+			}))
+			So(stores, ShouldHaveLength, 3)
+			So(stores[0].Distance, ShouldEqual, 0)
+			So(stores[1].Distance, ShouldAlmostEqual, 1.11, 0.01)
+			So(stores[2].Distance, ShouldBeGreaterThan, stores[1].Distance)
+		})
+	})
+}
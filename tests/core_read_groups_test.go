@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReadGroups(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	Convey("Read users with grouped conditions", t, func() {
+		Convey("Or group", func() {
+			users := UserModel.Where("occupation", "Witcher").Where("age", mocks.Vesemir.Age).
+				OrGroup(func(q elemental.Model[User]) elemental.Model[User] {
+					return q.Where("occupation", "Mage").Where("age").LessThan(mocks.Caranthir.Age)
+				}).ExecTT()
+			So(users, ShouldHaveLength, 2)
+			So(lo.Map(users, func(u User, _ int) string { return u.Name }), ShouldResemble, []string{mocks.Yennefer.Name, mocks.Vesemir.Name})
+		})
+		Convey("Or group in conjunction with find", func() {
+			users := UserModel.Find(primitive.M{"name": mocks.Geralt.Name}).
+				OrGroup(func(q elemental.Model[User]) elemental.Model[User] {
+					return q.Where("name", mocks.Ciri.Name)
+				}).ExecTT()
+			So(users, ShouldHaveLength, 2)
+		})
+		Convey("And group with a nested or condition", func() {
+			users := UserModel.Where("age").GreaterThan(50).
+				AndGroup(func(q elemental.Model[User]) elemental.Model[User] {
+					return q.Where("occupation", "General").OrWhere("name", mocks.Geralt.Name)
+				}).ExecTT()
+			So(users, ShouldHaveLength, 2)
+			So(lo.Map(users, func(u User, _ int) string { return u.Name }), ShouldContain, mocks.Imlerith.Name)
+		})
+		Convey("Nor group", func() {
+			users := UserModel.Where("age").GreaterThan(50).
+				NorGroup(func(q elemental.Model[User]) elemental.Model[User] {
+					return q.Where("occupation", "Witcher")
+				}).ExecTT()
+			So(users, ShouldHaveLength, len(lo.Filter(mocks.Users, func(u User, _ int) bool {
+				return u.Age > 50 && u.Occupation != "Witcher"
+			})))
+		})
+		Convey("Not condition", func() {
+			users := UserModel.Not("age").GreaterThan(50).ExecTT()
+			So(users, ShouldHaveLength, len(lo.Filter(mocks.Users, func(u User, _ int) bool {
+				return u.Age <= 50
+			})))
+		})
+		Convey("Nested groups", func() {
+			users := UserModel.Where("name", mocks.Ciri.Name).
+				OrGroup(func(q elemental.Model[User]) elemental.Model[User] {
+					return q.Where("occupation", "Witcher").AndGroup(func(q elemental.Model[User]) elemental.Model[User] {
+						return q.Not("age").Equals(mocks.Geralt.Age)
+					})
+				}).ExecTT()
+			So(users, ShouldHaveLength, 2)
+			So(lo.Map(users, func(u User, _ int) string { return u.Name }), ShouldContain, mocks.Vesemir.Name)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReadOptions(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	UserModel.SyncIndexes()
+
+	Convey("Query execution options", t, func() {
+		Convey("Hint an index by name", func() {
+			query := UserModel.Find(primitive.M{"age": primitive.M{"$gt": 100}}).Hint("name_1")
+			So(query.ExecTT(), ShouldHaveLength, 3)
+			So(query.Explain(elemental.ExplainQueryPlanner).IndexesUsed, ShouldContain, "name_1")
+		})
+		Convey("Hint an index by its keys", func() {
+			query := UserModel.Find().Hint(bson.D{{Key: "name", Value: 1}})
+			So(query.Explain(elemental.ExplainQueryPlanner).IndexScan, ShouldBeTrue)
+		})
+		Convey("Hint an index which does not exist", func() {
+			So(func() {
+				UserModel.Find().Hint("age_1").Exec()
+			}, ShouldPanic)
+		})
+		Convey("Combine all options", func() {
+			users := UserModel.Find().Sort("age", -1).
+				MaxTime(5 * time.Second).
+				AllowDiskUse().
+				Comment("combined options").
+				BatchSize(1).
+				ExecTT()
+			So(users, ShouldHaveLength, len(mocks.Users))
+			So(users[0].Name, ShouldEqual, mocks.Vesemir.Name)
+		})
+		Convey("Apply options to updates and deletes", func() {
+			So(func() {
+				UserModel.Hint("age_1").UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"age": 101}).Exec()
+			}, ShouldPanic)
+			So(func() {
+				UserModel.Hint("age_1").DeleteOne(primitive.M{"name": mocks.Geralt.Name}).Exec()
+			}, ShouldPanic)
+			So(UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT().Age, ShouldEqual, mocks.Geralt.Age)
+			UserModel.Hint("name_1").Comment("hinted update").
+				UpdateOne(&primitive.M{"name": mocks.Geralt.Name}, primitive.M{"occupation": "Vintner"}).Exec()
+			So(UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT().Occupation, ShouldEqual, "Vintner")
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReadPaginateCursor(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	names := func(users []User) []string {
+		return lo.Map(users, func(u User, _ int) string { return u.Name })
+	}
+
+	Convey("Find users with cursor pagination", t, func() {
+		Convey("Walk forward and backward through all pages", func() {
+			first := UserModel.Find().PaginateCursor(3, "").Exec().(elemental.CursorPaginateResult[User])
+			So(names(first.Docs), ShouldResemble, []string{mocks.Ciri.Name, mocks.Geralt.Name, mocks.Eredin.Name})
+			So(*first.TotalDocs, ShouldEqual, len(mocks.Users))
+			So(first.HasNext, ShouldBeTrue)
+			So(first.HasPrev, ShouldBeFalse)
+			So(first.PrevCursor, ShouldBeNil)
+			So(first.NextCursor, ShouldNotBeNil)
+
+			second := UserModel.Find().PaginateCursor(3, *first.NextCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(second.Docs), ShouldResemble, []string{mocks.Caranthir.Name, mocks.Imlerith.Name, mocks.Yennefer.Name})
+			So(second.HasNext, ShouldBeTrue)
+			So(second.HasPrev, ShouldBeTrue)
+
+			last := UserModel.Find().PaginateCursor(3, *second.NextCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(last.Docs), ShouldResemble, []string{mocks.Vesemir.Name})
+			So(last.HasNext, ShouldBeFalse)
+			So(last.NextCursor, ShouldBeNil)
+			So(last.HasPrev, ShouldBeTrue)
+
+			previous := UserModel.Find().PaginateCursor(3, *last.PrevCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(previous.Docs), ShouldResemble, names(second.Docs))
+			So(previous.HasNext, ShouldBeTrue)
+			So(previous.HasPrev, ShouldBeTrue)
+
+			firstAgain := UserModel.Find().PaginateCursor(3, *previous.PrevCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(firstAgain.Docs), ShouldResemble, names(first.Docs))
+			So(firstAgain.HasPrev, ShouldBeFalse)
+			So(firstAgain.PrevCursor, ShouldBeNil)
+		})
+		Convey("With a custom sort order", func() {
+			first := UserModel.Find().Sort("age", -1).PaginateCursor(2, "").Exec().(elemental.CursorPaginateResult[User])
+			So(names(first.Docs), ShouldResemble, []string{mocks.Vesemir.Name, mocks.Imlerith.Name})
+			second := UserModel.Find().Sort("age", -1).PaginateCursor(2, *first.NextCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(second.Docs), ShouldResemble, []string{mocks.Caranthir.Name, mocks.Geralt.Name})
+			third := UserModel.Find().Sort("age", -1).PaginateCursor(2, *second.NextCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(third.Docs), ShouldResemble, []string{mocks.Yennefer.Name, mocks.Ciri.Name})
+			fourth := UserModel.Find().Sort("age", -1).PaginateCursor(2, *third.NextCursor).Exec().(elemental.CursorPaginateResult[User])
+			So(names(fourth.Docs), ShouldResemble, []string{mocks.Eredin.Name})
+			So(fourth.HasNext, ShouldBeFalse)
+		})
+		Convey("Sorted on a field which is missing from some documents", func() {
+			for _, direction := range []int{1, -1} {
+				var walked []string
+				cursor := ""
+				for {
+					page := UserModel.Find().Sort("occupation", direction).PaginateCursor(2, cursor).Exec().(elemental.CursorPaginateResult[User])
+					walked = append(walked, names(page.Docs)...)
+					if !page.HasNext {
+						break
+					}
+					cursor = *page.NextCursor
+				}
+				So(walked, ShouldHaveLength, len(mocks.Users))
+				So(lo.Uniq(walked), ShouldHaveLength, len(mocks.Users))
+			}
+		})
+		Convey("With filters", func() {
+			result := UserModel.Find(primitive.M{"occupation": "Witcher"}).PaginateCursor(5, "").Exec().(elemental.CursorPaginateResult[User])
+			So(names(result.Docs), ShouldResemble, []string{mocks.Geralt.Name, mocks.Vesemir.Name})
+			So(*result.TotalDocs, ShouldEqual, 2)
+			So(result.HasNext, ShouldBeFalse)
+		})
+		Convey("Without counting the total documents", func() {
+			result := UserModel.Find().PaginateCursor(2, "", elemental.CursorPaginateOptions{SkipCount: true}).Exec().(elemental.CursorPaginateResult[User])
+			So(result.Docs, ShouldHaveLength, 2)
+			So(result.TotalDocs, ShouldBeNil)
+		})
+		Convey("With a tampered cursor", func() {
+			first := UserModel.Find().PaginateCursor(2, "").Exec().(elemental.CursorPaginateResult[User])
+			So(func() {
+				UserModel.Find().PaginateCursor(2, *first.NextCursor+"x").Exec()
+			}, ShouldPanicWith, elemental.ErrInvalidCursor)
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 	elemental "github.com/elcengine/elemental/core"
 	ts "github.com/elcengine/elemental/tests/fixtures/setup"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	. "github.com/smartystreets/goconvey/convey"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -219,4 +220,197 @@ func TestCoreReadPopulate(t *testing.T) {
 			SoDrowner(bestiaries[1])
 		})
 	})
+
+	type Company struct {
+		ID   primitive.ObjectID `json:"_id" bson:"_id"`
+		Name string             `json:"name" bson:"name"`
+	}
+	type Author struct {
+		ID      primitive.ObjectID `json:"_id" bson:"_id"`
+		Name    string             `json:"name" bson:"name"`
+		Company any                `json:"company" bson:"company"`
+	}
+	type Chapter struct {
+		Title    string `json:"title" bson:"title"`
+		Reviewer any    `json:"reviewer" bson:"reviewer"`
+	}
+	type Book struct {
+		ID        primitive.ObjectID   `json:"_id" bson:"_id"`
+		Title     string               `json:"title" bson:"title"`
+		Author    any                  `json:"author" bson:"author"`
+		Coauthors []primitive.ObjectID `json:"coauthors" bson:"coauthors"`
+		Chapters  []Chapter            `json:"chapters" bson:"chapters"`
+	}
+	type PopulatedAuthor struct {
+		ID      primitive.ObjectID `bson:"_id"`
+		Name    string             `bson:"name"`
+		Company *Company           `bson:"company"`
+	}
+	type PopulatedBook struct {
+		Title     string            `bson:"title"`
+		Author    PopulatedAuthor   `bson:"author"`
+		Coauthors []PopulatedAuthor `bson:"coauthors"`
+		Chapters  []struct {
+			Title    string           `bson:"title"`
+			Reviewer *PopulatedAuthor `bson:"reviewer"`
+		} `bson:"chapters"`
+	}
+
+	companyModelName, authorModelName := uuid.NewString(), uuid.NewString()
+	CompanyModel := elemental.NewModel[Company](companyModelName, elemental.NewSchema(map[string]elemental.Field{
+		"Name": {Type: elemental.String},
+	}, elemental.SchemaOptions{Collection: "companies"})).SetDatabase(t.Name())
+	AuthorModel := elemental.NewModel[Author](authorModelName, elemental.NewSchema(map[string]elemental.Field{
+		"Name":    {Type: elemental.String},
+		"Company": {Type: elemental.ObjectID, Ref: companyModelName},
+	}, elemental.SchemaOptions{Collection: "authors"})).SetDatabase(t.Name())
+	BookModel := elemental.NewModel[Book](uuid.NewString(), elemental.NewSchema(map[string]elemental.Field{
+		"Title":     {Type: elemental.String},
+		"Author":    {Type: elemental.ObjectID, Ref: authorModelName},
+		"Coauthors": {Type: elemental.ObjectIDSlice, Ref: authorModelName},
+		"Chapters": {Type: elemental.Slice, Schema: lo.ToPtr(elemental.NewSchema(map[string]elemental.Field{
+			"Reviewer": {Type: elemental.ObjectID, Ref: authorModelName},
+		}))},
+	}, elemental.SchemaOptions{Collection: "books"})).SetDatabase(t.Name())
+
+	company := CompanyModel.Create(Company{Name: "Oxenfurt Press"}).ExecT()
+	dandelion := AuthorModel.Create(Author{Name: "Dandelion", Company: company.ID}).ExecT()
+	priscilla := AuthorModel.Create(Author{Name: "Priscilla", Company: company.ID}).ExecT()
+	zoltan := AuthorModel.Create(Author{Name: "Zoltan"}).ExecT()
+	BookModel.Create(Book{
+		Title:     "Half a Century of Poetry",
+		Author:    dandelion.ID,
+		Coauthors: []primitive.ObjectID{zoltan.ID, priscilla.ID},
+		Chapters: []Chapter{
+			{Title: "Toss a Coin", Reviewer: priscilla.ID},
+			{Title: "The Wolven Storm", Reviewer: zoltan.ID},
+		},
+	}).Exec()
+
+	Convey("Find with deeply populated fields", t, func() {
+		Convey("Dotted path through a reference", func() {
+			var books []PopulatedBook
+			BookModel.Find().Populate("author.company").ExecInto(&books)
+			So(books, ShouldHaveLength, 1)
+			So(books[0].Author.Name, ShouldEqual, "Dandelion")
+			So(books[0].Author.Company.Name, ShouldEqual, "Oxenfurt Press")
+			Convey("Combined with a populate of the same reference", func() {
+				var books []PopulatedBook
+				BookModel.Find().Populate("author", "author.company").ExecInto(&books)
+				So(books, ShouldHaveLength, 1)
+				So(books[0].Author.Company.Name, ShouldEqual, "Oxenfurt Press")
+			})
+		})
+		Convey("Nested populate option", func() {
+			var books []PopulatedBook
+			BookModel.Find().Populate(primitive.M{"path": "author", "populate": "company"}).ExecInto(&books)
+			So(books[0].Author.Company.Name, ShouldEqual, "Oxenfurt Press")
+		})
+		Convey("Path within an array of subdocuments", func() {
+			var books []PopulatedBook
+			BookModel.Find().Populate("chapters.reviewer").ExecInto(&books)
+			So(books[0].Chapters, ShouldHaveLength, 2)
+			So(books[0].Chapters[0].Title, ShouldEqual, "Toss a Coin")
+			So(books[0].Chapters[0].Reviewer.Name, ShouldEqual, "Priscilla")
+			So(books[0].Chapters[1].Reviewer.Name, ShouldEqual, "Zoltan")
+			Convey("And within the referenced documents", func() {
+				var books []PopulatedBook
+				BookModel.Find().Populate("chapters.reviewer.company").ExecInto(&books)
+				So(books[0].Chapters[0].Reviewer.Company.Name, ShouldEqual, "Oxenfurt Press")
+				So(books[0].Chapters[1].Reviewer.Company, ShouldBeNil)
+			})
+		})
+		Convey("Slice of references", func() {
+			var books []PopulatedBook
+			BookModel.Find().Populate("coauthors").ExecInto(&books)
+			So(books[0].Coauthors, ShouldHaveLength, 2)
+			Convey("With match, sort and limit", func() {
+				var books []PopulatedBook
+				BookModel.Find().Populate(primitive.M{
+					"path":  "coauthors",
+					"match": primitive.M{"name": primitive.M{"$ne": "Nobody"}},
+					"sort":  primitive.M{"name": 1},
+					"limit": 1,
+				}).ExecInto(&books)
+				So(books[0].Coauthors, ShouldHaveLength, 1)
+				So(books[0].Coauthors[0].Name, ShouldEqual, "Priscilla")
+			})
+			Convey("With a match which excludes every reference", func() {
+				var books []PopulatedBook
+				BookModel.Find().Populate(primitive.M{
+					"path":  "coauthors",
+					"match": primitive.M{"name": "Nobody"},
+				}).ExecInto(&books)
+				So(books[0].Coauthors, ShouldBeEmpty)
+			})
+		})
+		Convey("With OrFail", func() {
+			So(func() {
+				BookModel.Find(primitive.M{"title": "Unwritten"}).Populate("author.company").OrFail().Exec()
+			}, ShouldPanicWith, errors.New("no results found matching the given query"))
+			So(func() {
+				var books []PopulatedBook
+				BookModel.Find(primitive.M{"title": "Unwritten"}).Populate("chapters.reviewer").OrFail().ExecInto(&books)
+			}, ShouldPanicWith, errors.New("no results found matching the given query"))
+		})
+	})
+
+	Convey("Find with populated virtual relations", t, func() {
+		KingdomModel := elemental.NewModel[Kingdom](uuid.NewString(), elemental.NewSchema(KingdomModel.Schema.Definitions, elemental.SchemaOptions{
+			Collection: "kingdoms",
+			Virtuals: map[string]elemental.Virtual{
+				"bestiaries": {
+					Ref:          BestiaryModel.Name,
+					ForeignField: "kingdom",
+				},
+				"bestiary": {
+					Ref:          BestiaryModel.Name,
+					ForeignField: "kingdom",
+					JustOne:      true,
+				},
+				"bestiary_count": {
+					Collection:   "bestiary",
+					ForeignField: "kingdom",
+					Count:        true,
+				},
+			},
+		})).SetDatabase(t.Name())
+		type KingdomWithBestiaries struct {
+			Name          string                                        `bson:"name"`
+			Bestiaries    []Bestiary                                    `bson:"bestiaries"`
+			Bestiary      *GenericBestiary[Monster, primitive.ObjectID] `bson:"bestiary"`
+			BestiaryCount int                                           `bson:"bestiary_count"`
+		}
+		Convey("One to many", func() {
+			var results []KingdomWithBestiaries
+			KingdomModel.Find().Sort("name", 1).Populate("bestiaries").ExecInto(&results)
+			So(results, ShouldHaveLength, 3)
+			So(results[0].Name, ShouldEqual, "Nilfgaard")
+			So(results[0].Bestiaries, ShouldHaveLength, 1)
+			So(results[0].Bestiaries[0].Monster, ShouldEqual, monsters[0].ID)
+		})
+		Convey("Just one with a nested populate", func() {
+			var results []KingdomWithBestiaries
+			KingdomModel.Find(primitive.M{"name": "Redania"}).Populate("bestiary.monster").ExecInto(&results)
+			So(results, ShouldHaveLength, 1)
+			So(results[0].Bestiary, ShouldNotBeNil)
+			So(results[0].Bestiary.Monster.Name, ShouldEqual, "Drowner")
+		})
+		Convey("Count", func() {
+			var results []KingdomWithBestiaries
+			KingdomModel.Find().Populate("bestiary_count").ExecInto(&results)
+			So(results, ShouldHaveLength, 3)
+			for _, result := range results {
+				So(result.BestiaryCount, ShouldEqual, 1)
+			}
+		})
+		Convey("With a match which excludes every related document", func() {
+			var results []KingdomWithBestiaries
+			KingdomModel.Find(primitive.M{"name": "Skellige"}).Populate(primitive.M{
+				"path":  "bestiary",
+				"match": primitive.M{"monster": monsters[0].ID},
+			}).ExecInto(&results)
+			So(results[0].Bestiary, ShouldBeNil)
+		})
+	})
 }
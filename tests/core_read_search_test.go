@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestCoreReadSearch(t *testing.T) {
+	t.Parallel()
+
+	ts.Connection(t.Name())
+
+	MonsterModel := elemental.NewModel[Monster]("Monster-For-Search", elemental.NewSchema(map[string]elemental.Field{
+		"Name": {
+			Type:       elemental.String,
+			Required:   true,
+			TextIndex:  true,
+			TextWeight: 10,
+		},
+		"Category": {
+			Type:      elemental.String,
+			TextIndex: true,
+		},
+	}, elemental.SchemaOptions{
+		Collection:       "monsters_for_search",
+		TextIndexOptions: options.Index().SetDefaultLanguage("english"),
+	})).SetDatabase(t.Name())
+
+	MonsterModel.SyncIndexes()
+
+	MonsterModel.InsertMany([]Monster{
+		{Name: "Drowner", Category: "Necrophage"},
+		{Name: "Griffin", Category: "Hybrid"},
+		{Name: "Royal Wyvern", Category: "Draconid"},
+		{Name: "Archgriffin", Category: "Griffin like hybrid"},
+		{Name: "Ekimmara", Category: "Vampire"},
+	}).Exec()
+
+	names := func(monsters []Monster) []string {
+		return lo.Map(monsters, func(m Monster, _ int) string { return m.Name })
+	}
+
+	Convey("Search monsters", t, func() {
+		Convey("Search a term", func() {
+			monsters := MonsterModel.Search("griffin").ExecTT()
+			So(names(monsters), ShouldHaveLength, 2)
+			So(names(monsters), ShouldContain, "Griffin")
+			So(names(monsters), ShouldContain, "Archgriffin")
+		})
+		Convey("Search a term which matches stemmed words", func() {
+			monsters := MonsterModel.Search("hybrids").ExecTT()
+			So(names(monsters), ShouldHaveLength, 2)
+		})
+		Convey("Search with case sensitivity", func() {
+			So(MonsterModel.Search("HYBRID", elemental.SearchOptions{CaseSensitive: true}).ExecTT(), ShouldBeEmpty)
+			So(MonsterModel.Search("Hybrid", elemental.SearchOptions{CaseSensitive: true}).ExecTT(), ShouldHaveLength, 1)
+		})
+		Convey("Search in conjunction with other filters", func() {
+			monsters := MonsterModel.Where("category", "Hybrid").Search("griffin").ExecTT()
+			So(names(monsters), ShouldResemble, []string{"Griffin"})
+		})
+		Convey("Sort by relevance and project the score", func() {
+			type ScoredMonster struct {
+				Name  string  `bson:"name"`
+				Score float64 `bson:"score"`
+			}
+			monsters := elemental.Aggregate[ScoredMonster](MonsterModel.Search("griffin", elemental.SearchOptions{
+				SortByScore: true,
+				ScoreField:  "score",
+			}))
+			So(monsters, ShouldHaveLength, 2)
+			So(monsters[0].Name, ShouldEqual, "Griffin")
+			So(monsters[0].Score, ShouldBeGreaterThan, monsters[1].Score)
+			So(monsters[1].Score, ShouldBeGreaterThan, 0)
+		})
+	})
+
+	Convey("Search soft deletable monsters", t, func() {
+		SoftDeleteMonsterModel := elemental.NewModel[Monster]("Monster-For-Search-With-Soft-Delete", elemental.NewSchema(map[string]elemental.Field{
+			"Name": {
+				Type:      elemental.String,
+				Required:  true,
+				TextIndex: true,
+			},
+		}, elemental.SchemaOptions{
+			Collection: "soft_deletable_monsters_for_search",
+			SoftDelete: true,
+		})).SetDatabase(t.Name())
+		SoftDeleteMonsterModel.SyncIndexes()
+		SoftDeleteMonsterModel.InsertMany([]Monster{{Name: "Griffin"}, {Name: "Royal Griffin"}}).Exec()
+		SoftDeleteMonsterModel.DeleteOne(primitive.M{"name": "Royal Griffin"}).Exec()
+		So(names(SoftDeleteMonsterModel.Search("griffin").ExecTT()), ShouldResemble, []string{"Griffin"})
+	})
+}
@@ -7,6 +7,7 @@ import (
 	elemental "github.com/elcengine/elemental/core"
 	"github.com/elcengine/elemental/tests/fixtures/mocks"
 	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
 
 	. "github.com/smartystreets/goconvey/convey"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -164,5 +165,26 @@ func TestCoreRead(t *testing.T) {
 			So(schools, ShouldContain, mocks.WolfSchool)
 			So(schools, ShouldContain, "")
 		})
+		Convey("Find distinct values of their actual type", func() {
+			So(elemental.DistinctT[int](UserModel, "age"), ShouldResemble, []int{0, 100, 120, 150, 300})
+			So(elemental.DistinctT[bool](UserModel, "retired"), ShouldResemble, []bool{false, true})
+			ids := elemental.DistinctT[primitive.ObjectID](UserModel.Where("occupation", "Mage"), "_id")
+			So(ids, ShouldHaveLength, 2)
+			So(ids[0].IsZero(), ShouldBeFalse)
+			So(elemental.DistinctT[string](UserModel, "school"), ShouldResemble, []string{mocks.WolfSchool})
+		})
+		Convey("Find distinct values of an array field", func() {
+			weapons := elemental.DistinctT[string](UserModel, "weapons")
+			So(weapons, ShouldContain, "Battle Axe")
+			So(weapons, ShouldContain, "Staff")
+			So(lo.Uniq(weapons), ShouldHaveLength, len(weapons))
+		})
+		Convey("Count the occurrences of distinct values", func() {
+			weapons := elemental.DistinctWithCount[string](UserModel, "weapons")
+			So(weapons[0].Count, ShouldBeGreaterThanOrEqualTo, weapons[len(weapons)-1].Count)
+			axe, _ := lo.Find(weapons, func(w elemental.DistinctValue[string]) bool { return w.Value == "Battle Axe" })
+			So(axe.Count, ShouldEqual, 2)
+			So(elemental.DistinctWithCount[int](UserModel.Where("age").GreaterThan(1000), "age"), ShouldBeEmpty)
+		})
 	})
 }
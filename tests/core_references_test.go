@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreReferences(t *testing.T) {
+	t.Parallel()
+
+	ts.Connection(t.Name())
+
+	type Realm struct {
+		ID   primitive.ObjectID `json:"_id" bson:"_id"`
+		Name string             `json:"name" bson:"name"`
+	}
+
+	type Castle struct {
+		ID    primitive.ObjectID `json:"_id" bson:"_id"`
+		Name  string             `json:"name" bson:"name"`
+		Realm primitive.ObjectID `json:"realm" bson:"realm"`
+	}
+
+	type Knight struct {
+		ID          primitive.ObjectID   `json:"_id" bson:"_id"`
+		Name        string               `json:"name" bson:"name"`
+		Realm       *primitive.ObjectID  `json:"realm" bson:"realm"`
+		Allegiances []primitive.ObjectID `json:"allegiances" bson:"allegiances"`
+	}
+
+	type Treaty struct {
+		ID    primitive.ObjectID `json:"_id" bson:"_id"`
+		Name  string             `json:"name" bson:"name"`
+		Realm primitive.ObjectID `json:"realm" bson:"signatory"`
+	}
+
+	RealmModel := elemental.NewModel[Realm]("Realm-With-References", elemental.NewSchema(map[string]elemental.Field{
+		"Name": {
+			Type:     elemental.String,
+			Required: true,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "realms",
+	})).SetDatabase(t.Name())
+
+	CastleModel := elemental.NewModel[Castle]("Castle-Of-Realm", elemental.NewSchema(map[string]elemental.Field{
+		"Realm": {
+			Type:     elemental.ObjectID,
+			Ref:      "Realm-With-References",
+			OnDelete: elemental.Cascade,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "castles",
+		SoftDelete: true,
+	})).SetDatabase(t.Name())
+
+	KnightModel := elemental.NewModel[Knight]("Knight-Of-Realm", elemental.NewSchema(map[string]elemental.Field{
+		"Realm": {
+			Type:     elemental.ObjectID,
+			Ref:      "Realm-With-References",
+			OnDelete: elemental.SetNull,
+		},
+		"Allegiances": {
+			Type:     elemental.ObjectIDSlice,
+			Ref:      "Realm-With-References",
+			OnDelete: elemental.SetNull,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "knights",
+	})).SetDatabase(t.Name())
+
+	TreatyModel := elemental.NewModel[Treaty]("Treaty-Of-Realm", elemental.NewSchema(map[string]elemental.Field{
+		"Realm": {
+			Type:     elemental.ObjectID,
+			Ref:      "Realm-With-References",
+			OnDelete: elemental.Restrict,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "treaties",
+		SoftDelete: true,
+	})).SetDatabase(t.Name())
+
+	realms := RealmModel.InsertMany([]Realm{{Name: "Temeria"}, {Name: "Redania"}, {Name: "Kaedwen"}, {Name: "Aedirn"}}).ExecTT()
+	temeria, redania, kaedwen, aedirn := realms[0], realms[1], realms[2], realms[3]
+
+	CastleModel.InsertMany([]Castle{
+		{Name: "Vizima Castle", Realm: temeria.ID},
+		{Name: "Maribor Keep", Realm: temeria.ID},
+		{Name: "Tretogor Castle", Realm: redania.ID},
+	}).Exec()
+	KnightModel.InsertMany([]Knight{
+		{Name: "Vernon Roche", Realm: &temeria.ID, Allegiances: []primitive.ObjectID{temeria.ID, redania.ID}},
+		{Name: "Ves", Realm: &temeria.ID, Allegiances: []primitive.ObjectID{temeria.ID}},
+		{Name: "Dijkstra", Realm: &redania.ID, Allegiances: []primitive.ObjectID{redania.ID}},
+	}).Exec()
+	TreatyModel.InsertMany([]Treaty{
+		{Name: "Treaty of Cintra", Realm: kaedwen.ID},
+		{Name: "Pact of Dol Blathanna", Realm: aedirn.ID},
+	}).Exec()
+
+	knight := func(name string) Knight {
+		return KnightModel.FindOne(primitive.M{"name": name}).ExecT()
+	}
+
+	Convey("Enforce the on delete actions of referencing models", t, func() {
+		RealmModel.DeleteByID(temeria.ID).Exec()
+		Convey("Soft delete cascaded documents", func() {
+			So(lo.Map(CastleModel.Find().ExecTT(), func(c Castle, _ int) string { return c.Name }), ShouldResemble, []string{"Tretogor Castle"})
+			So(CastleModel.OnlyTrashed().CountDocuments().Exec(), ShouldEqual, 2)
+		})
+		Convey("Nullify single references and pull them from arrays", func() {
+			roche := knight("Vernon Roche")
+			So(roche.Realm, ShouldBeNil)
+			So(roche.Allegiances, ShouldResemble, []primitive.ObjectID{redania.ID})
+			So(knight("Ves").Allegiances, ShouldBeEmpty)
+			So(*knight("Dijkstra").Realm, ShouldEqual, redania.ID)
+		})
+	})
+
+	Convey("Refuse to delete documents which are still referenced", t, func() {
+		So(func() {
+			RealmModel.DeleteMany(primitive.M{"name": primitive.M{"$in": []string{"Redania", "Kaedwen"}}}).Exec()
+		}, ShouldPanic)
+		So(RealmModel.CountDocuments().Exec(), ShouldEqual, 3)
+		So(CastleModel.CountDocuments(primitive.M{"realm": redania.ID}).Exec(), ShouldEqual, 1)
+		So(*knight("Dijkstra").Realm, ShouldEqual, redania.ID)
+	})
+
+	Convey("Ignore soft deleted referencing documents when restricting", t, func() {
+		TreatyModel.DeleteOne(primitive.M{"signatory": aedirn.ID}).Exec()
+		So(func() {
+			RealmModel.FindByIDAndDelete(aedirn.ID).Exec()
+		}, ShouldNotPanic)
+		So(RealmModel.FindByID(aedirn.ID).Exec(), ShouldBeNil)
+	})
+
+	Convey("Delete along with the on delete actions within a transaction", t, func() {
+		RealmModel.InTransaction().DeleteByID(redania.ID).Exec()
+		So(RealmModel.FindByID(redania.ID).Exec(), ShouldBeNil)
+		So(CastleModel.CountDocuments().Exec(), ShouldEqual, 0)
+		So(knight("Dijkstra").Realm, ShouldBeNil)
+		So(knight("Vernon Roche").Allegiances, ShouldBeEmpty)
+	})
+
+	Convey("Delete only the documents whose references were acted upon", t, func() {
+		RealmModel.InsertMany([]Realm{{Name: "Nilfgaard"}, {Name: "Skellige"}}).Exec()
+		RealmModel.Where("name", "Nilfgaard").DeleteMany().Exec()
+		So(RealmModel.FindOne(primitive.M{"name": "Nilfgaard"}).Exec(), ShouldBeNil)
+		So(RealmModel.FindOne(primitive.M{"name": "Skellige"}).Exec(), ShouldNotBeNil)
+	})
+}
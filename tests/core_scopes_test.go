@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCoreScopes(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	names := func(users []User) []string {
+		return lo.Map(users, func(u User, _ int) string { return u.Name })
+	}
+
+	ScopedUserModel := elemental.NewModel[User]("User-With-Scopes", elemental.NewSchema(UserModel.Schema.Definitions, elemental.SchemaOptions{
+		Collection: "users",
+	})).SetDatabase(t.Name())
+
+	ScopedUserModel.AddScope("witchers", func(m elemental.Model[User]) elemental.Model[User] {
+		return m.Where("occupation", "Witcher")
+	})
+	ScopedUserModel.AddScope("elders", func(m elemental.Model[User]) elemental.Model[User] {
+		return m.Where("age").GreaterThan(100)
+	})
+
+	ActiveUserModel := elemental.NewModel[User]("User-With-Global-Scopes", elemental.NewSchema(UserModel.Schema.Definitions, elemental.SchemaOptions{
+		Collection: "users",
+	})).SetDatabase(t.Name())
+
+	ActiveUserModel.AddGlobalScope("active", func(m elemental.Model[User]) elemental.Model[User] {
+		return m.Where("retired", false)
+	})
+
+	Convey("Apply named scopes", t, func() {
+		Convey("A single scope", func() {
+			So(names(ScopedUserModel.Find().Scoped("witchers").ExecTT()), ShouldResemble, []string{mocks.Geralt.Name, mocks.Vesemir.Name})
+		})
+		Convey("Multiple scopes along with other filters", func() {
+			So(names(ScopedUserModel.Find().Scoped("witchers", "elders").ExecTT()), ShouldResemble, []string{mocks.Vesemir.Name})
+			So(ScopedUserModel.Where("name", mocks.Geralt.Name).Scoped("elders").ExecTT(), ShouldBeEmpty)
+		})
+		Convey("A scope which has not been registered", func() {
+			So(func() {
+				ScopedUserModel.Find().Scoped("mages")
+			}, ShouldPanic)
+		})
+		Convey("Named scopes do not apply unless requested", func() {
+			So(ScopedUserModel.Find().ExecTT(), ShouldHaveLength, len(mocks.Users))
+		})
+	})
+
+	Convey("Apply global scopes", t, func() {
+		Convey("To reads", func() {
+			So(names(ActiveUserModel.Find().ExecTT()), ShouldNotContain, mocks.Vesemir.Name)
+			So(ActiveUserModel.CountDocuments().Exec(), ShouldEqual, len(mocks.Users)-1)
+			So(ActiveUserModel.FindOne(primitive.M{"name": mocks.Vesemir.Name}).Exec(), ShouldBeNil)
+		})
+		Convey("Unless the query opts out of them", func() {
+			So(ActiveUserModel.Find().WithoutScope("active").ExecTT(), ShouldHaveLength, len(mocks.Users))
+			So(ActiveUserModel.FindOne(primitive.M{"name": mocks.Vesemir.Name}).WithoutScope("active").ExecT().Name, ShouldEqual, mocks.Vesemir.Name)
+		})
+		Convey("Combined with filters on the same field", func() {
+			So(ActiveUserModel.Find(primitive.M{"retired": true}).ExecTT(), ShouldBeEmpty)
+		})
+		Convey("To updates and deletes", func() {
+			ActiveUserModel := ActiveUserModel.SetCollection("scoped_users")
+			ActiveUserModel.InsertMany(mocks.Users).Exec()
+			result := ActiveUserModel.UpdateMany(nil, primitive.M{"occupation": "Refugee"}).Exec().(*mongo.UpdateResult)
+			So(result.MatchedCount, ShouldEqual, len(mocks.Users)-1)
+			So(ActiveUserModel.FindOne(primitive.M{"name": mocks.Vesemir.Name}).WithoutScope("active").ExecT().Occupation, ShouldEqual, mocks.Vesemir.Occupation)
+			ActiveUserModel.DeleteMany().Exec()
+			So(names(ActiveUserModel.Find().WithoutScope("active").ExecTT()), ShouldResemble, []string{mocks.Vesemir.Name})
+		})
+	})
+
+	Convey("Exclude soft deleted documents through a global scope", t, func() {
+		SoftDeleteUserModel := UserModel.SetDatabase(t.Name()).SetCollection("soft_deleted_scoped_users")
+		SoftDeleteUserModel.EnableSoftDelete()
+		SoftDeleteUserModel.InsertMany(mocks.Users).Exec()
+		SoftDeleteUserModel.DeleteOne(primitive.M{"name": mocks.Eredin.Name}).Exec()
+		So(names(SoftDeleteUserModel.Find().ExecTT()), ShouldNotContain, mocks.Eredin.Name)
+		So(names(SoftDeleteUserModel.Find().WithoutScope(elemental.SoftDeleteScope).ExecTT()), ShouldContain, mocks.Eredin.Name)
+		result := SoftDeleteUserModel.UpdateOne(&primitive.M{"name": mocks.Eredin.Name}, primitive.M{"age": 1000}).Exec().(*mongo.UpdateResult)
+		So(result.MatchedCount, ShouldEqual, 0)
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
+	elemental "github.com/elcengine/elemental/core"
 	"github.com/elcengine/elemental/tests/fixtures/mocks"
 	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/samber/lo"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -93,3 +96,108 @@ func TestCoreSoftDelete(t *testing.T) {
 		})
 	})
 }
+
+func TestCoreSoftDeleteTrashed(t *testing.T) {
+	t.Parallel()
+
+	ts.Connection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name()).SetCollection("trashed_users")
+
+	UserModel.EnableSoftDelete()
+
+	UserModel.InsertMany(mocks.Users).Exec()
+
+	UserModel.DeleteMany(primitive.M{"occupation": "Mage"}).Exec()
+
+	names := func(users []User) []string {
+		return lo.Map(users, func(u User, _ int) string { return u.Name })
+	}
+
+	type Guild struct {
+		ID   primitive.ObjectID `json:"_id" bson:"_id"`
+		Name string             `json:"name" bson:"name"`
+	}
+
+	type GenericMember[G any] struct {
+		ID    primitive.ObjectID `json:"_id" bson:"_id"`
+		Name  string             `json:"name" bson:"name"`
+		Guild G                  `json:"guild" bson:"guild"`
+	}
+
+	GuildModel := elemental.NewModel[Guild]("Guild-With-Soft-Delete", elemental.NewSchema(map[string]elemental.Field{
+		"Name": {
+			Type:     elemental.String,
+			Required: true,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "guilds",
+		SoftDelete: true,
+	})).SetDatabase(t.Name())
+
+	MemberModel := elemental.NewModel[GenericMember[any]]("Member-Of-Soft-Deleted-Guild", elemental.NewSchema(map[string]elemental.Field{
+		"Guild": {
+			Type: elemental.ObjectID,
+			Ref:  "Guild-With-Soft-Delete",
+		},
+	}, elemental.SchemaOptions{
+		Collection: "members",
+	})).SetDatabase(t.Name())
+
+	lodge := GuildModel.Create(Guild{Name: "Lodge of Sorceresses"}).ExecT()
+	brotherhood := GuildModel.Create(Guild{Name: "Brotherhood of Sorcerers"}).ExecT()
+	MemberModel.InsertMany([]GenericMember[any]{
+		{Name: mocks.Yennefer.Name, Guild: lodge.ID},
+		{Name: mocks.Caranthir.Name, Guild: brotherhood.ID},
+	}).Exec()
+	GuildModel.DeleteByID(brotherhood.ID).Exec()
+
+	Convey("Query soft deleted users", t, func() {
+		Convey("Hide them from every read", func() {
+			So(names(UserModel.Find().ExecTT()), ShouldNotContain, mocks.Yennefer.Name)
+			So(UserModel.CountDocuments().Exec(), ShouldEqual, len(mocks.Users)-2)
+			So(UserModel.Distinct("occupation").Exec(), ShouldNotContain, "Mage")
+			So(UserModel.Where("occupation", "Mage").Exec(), ShouldBeEmpty)
+			So(UserModel.Paginate(1, 10).Exec().(elemental.PaginateResult[User]).TotalDocs, ShouldEqual, len(mocks.Users)-2)
+		})
+		Convey("Include them along with all other users", func() {
+			So(UserModel.Find().WithTrashed().ExecTT(), ShouldHaveLength, len(mocks.Users))
+			So(UserModel.WithTrashed().CountDocuments().Exec(), ShouldEqual, len(mocks.Users))
+		})
+		Convey("Return only them", func() {
+			So(names(UserModel.Find().OnlyTrashed().ExecTT()), ShouldResemble, []string{mocks.Caranthir.Name, mocks.Yennefer.Name})
+			So(UserModel.OnlyTrashed().CountDocuments().Exec(), ShouldEqual, 2)
+		})
+		Convey("Leave them untouched by updates", func() {
+			result := UserModel.UpdateMany(&primitive.M{"occupation": "Mage"}, primitive.M{"age": 1}).Exec().(*mongo.UpdateResult)
+			So(result.MatchedCount, ShouldEqual, 0)
+		})
+		Convey("Hide them from populated references", func() {
+			var members []GenericMember[Guild]
+			MemberModel.Find().Populate("guild").ExecInto(&members)
+			So(members, ShouldHaveLength, 2)
+			So(members[0].Guild.Name, ShouldEqual, lodge.Name)
+			So(members[1].Guild.ID.IsZero(), ShouldBeTrue)
+		})
+	})
+
+	Convey("Restore soft deleted users", t, func() {
+		yennefer := UserModel.Find(primitive.M{"name": mocks.Yennefer.Name}).WithTrashed().ExecTT()[0]
+		result := UserModel.RestoreByID(yennefer.ID).Exec().(*mongo.UpdateResult)
+		So(result.ModifiedCount, ShouldEqual, 1)
+		So(UserModel.FindByID(yennefer.ID).ExecT().Name, ShouldEqual, mocks.Yennefer.Name)
+		So(UserModel.Restore(primitive.M{"name": mocks.Geralt.Name}).Exec().(*mongo.UpdateResult).MatchedCount, ShouldEqual, 0)
+		UserModel.Restore().Exec()
+		So(UserModel.Find().ExecTT(), ShouldHaveLength, len(mocks.Users))
+	})
+
+	Convey("Force delete users", t, func() {
+		UserModel.DeleteOne(primitive.M{"name": mocks.Ciri.Name}).Exec()
+		UserModel.ForceDelete(primitive.M{"name": primitive.M{"$in": []string{mocks.Ciri.Name, mocks.Eredin.Name}}}).Exec()
+		So(UserModel.Find().WithTrashed().ExecTT(), ShouldHaveLength, len(mocks.Users)-2)
+		geralt := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT()
+		UserModel.ForceDeleteByID(geralt.ID).Exec()
+		count, _ := UserModel.Collection().CountDocuments(context.Background(), primitive.M{"_id": geralt.ID})
+		So(count, ShouldEqual, 0)
+	})
+}
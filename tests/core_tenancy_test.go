@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	"github.com/elcengine/elemental/utils"
+	"github.com/samber/lo"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCoreTenancy(t *testing.T) {
+	t.Parallel()
+
+	ts.Connection(t.Name())
+
+	type Contract struct {
+		ID       primitive.ObjectID `json:"_id" bson:"_id"`
+		TenantID string             `json:"tenant_id" bson:"tenant_id,omitempty"`
+		Monster  string             `json:"monster" bson:"monster"`
+		Reward   int                `json:"reward" bson:"reward"`
+	}
+
+	definitions := map[string]elemental.Field{
+		"Monster": {
+			Type:     elemental.String,
+			Required: true,
+		},
+		"Reward": {
+			Type: elemental.Int,
+		},
+	}
+
+	ContractModel := elemental.NewModel[Contract]("Contract-With-Tenancy", elemental.NewSchema(definitions, elemental.SchemaOptions{
+		Collection: "contracts",
+		Tenancy:    &elemental.Tenancy{Mode: elemental.TenancyModeField},
+	})).SetDatabase(t.Name())
+
+	kaerMorhen := elemental.WithTenant(context.Background(), "kaer-morhen")
+	novigrad := elemental.WithTenant(context.Background(), "novigrad")
+
+	ContractModel.Create(Contract{Monster: "Griffin", Reward: 200}).Exec(kaerMorhen)
+	ContractModel.InsertMany([]Contract{{Monster: "Wyvern", Reward: 300}, {Monster: "Drowner", Reward: 20}}).Exec(kaerMorhen)
+	ContractModel.Create(Contract{Monster: "Ghoul", Reward: 50}).Exec(novigrad)
+
+	monsters := func(contracts []Contract) []string {
+		return lo.Map(contracts, func(c Contract, _ int) string { return c.Monster })
+	}
+
+	Convey("Isolate documents by a tenant field", t, func() {
+		Convey("Stamp the tenant on inserted documents", func() {
+			contracts := ContractModel.Find().ExecTT(kaerMorhen)
+			So(contracts, ShouldHaveLength, 3)
+			So(lo.Uniq(lo.Map(contracts, func(c Contract, _ int) string { return c.TenantID })), ShouldResemble, []string{"kaer-morhen"})
+		})
+		Convey("Only read the documents of the tenant", func() {
+			So(monsters(ContractModel.Find().ExecTT(novigrad)), ShouldResemble, []string{"Ghoul"})
+			So(ContractModel.CountDocuments().Exec(kaerMorhen), ShouldEqual, 3)
+			So(ContractModel.FindOne(primitive.M{"monster": "Ghoul"}).Exec(kaerMorhen), ShouldBeNil)
+			So(elemental.AggregateInto[[]Contract](ContractModel.Where("reward").GreaterThan(25), novigrad), ShouldHaveLength, 1)
+		})
+		Convey("Only update and delete the documents of the tenant", func() {
+			result := ContractModel.UpdateMany(nil, primitive.M{"reward": 500}).Exec(novigrad).(*mongo.UpdateResult)
+			So(result.MatchedCount, ShouldEqual, 1)
+			So(ContractModel.FindOne(primitive.M{"monster": "Griffin"}).ExecT(kaerMorhen).Reward, ShouldEqual, 200)
+			ContractModel.DeleteMany(primitive.M{"monster": "Griffin"}).Exec(novigrad)
+			So(ContractModel.CountDocuments().Exec(kaerMorhen), ShouldEqual, 3)
+		})
+		Convey("Fail without a tenant", func() {
+			So(func() {
+				ContractModel.Find().Exec()
+			}, ShouldPanicWith, elemental.ErrTenantRequired)
+			So(func() {
+				ContractModel.Create(Contract{Monster: "Nekker"}).Exec()
+			}, ShouldPanicWith, elemental.ErrTenantRequired)
+			So(func() {
+				ContractModel.Bulk().InsertOne(Contract{Monster: "Nekker"}).Exec()
+			}, ShouldPanicWith, elemental.ErrTenantRequired)
+			So(func() {
+				ContractModel.Find().Explain(elemental.ExplainQueryPlanner)
+			}, ShouldPanicWith, elemental.ErrTenantRequired)
+		})
+		Convey("Refuse documents of another tenant", func() {
+			So(func() {
+				ContractModel.Create(Contract{TenantID: "novigrad", Monster: "Nekker"}).Exec(kaerMorhen)
+			}, ShouldPanicWith, elemental.ErrTenantMismatch)
+		})
+	})
+
+	Convey("Upsert documents by the same key within each tenant", t, func() {
+		ContractModel.UpsertMany([]Contract{{Monster: "Bruxa", Reward: 700}}, "monster").Exec(kaerMorhen)
+		result := ContractModel.UpsertMany([]Contract{{Monster: "Bruxa", Reward: 900}}, "monster").Exec(novigrad)
+		So(result.UpsertedCount, ShouldEqual, 1)
+		So(ContractModel.FindOne(primitive.M{"monster": "Bruxa"}).ExecT(kaerMorhen).Reward, ShouldEqual, 700)
+		So(ContractModel.FindOne(primitive.M{"monster": "Bruxa"}).ExecT(novigrad).Reward, ShouldEqual, 900)
+	})
+
+	Convey("Populate only the referenced documents of the tenant", t, func() {
+		type Hunter struct {
+			ID       primitive.ObjectID `json:"_id" bson:"_id"`
+			TenantID string             `json:"tenant_id" bson:"tenant_id,omitempty"`
+			Name     string             `json:"name" bson:"name"`
+			Contract any                `json:"contract" bson:"contract"`
+		}
+		HunterModel := elemental.NewModel[Hunter]("Hunter-With-Tenancy", elemental.NewSchema(map[string]elemental.Field{
+			"Contract": {
+				Type: elemental.ObjectID,
+				Ref:  "Contract-With-Tenancy",
+			},
+		}, elemental.SchemaOptions{
+			Collection: "hunters",
+			Tenancy:    &elemental.Tenancy{Mode: elemental.TenancyModeField},
+		})).SetDatabase(t.Name())
+		katakan := ContractModel.Create(Contract{Monster: "Katakan", Reward: 800}).ExecT(kaerMorhen)
+		HunterModel.Create(Hunter{Name: "Lambert", Contract: katakan.ID}).Exec(kaerMorhen)
+		HunterModel.Create(Hunter{Name: "Vernossiel", Contract: katakan.ID}).Exec(novigrad)
+		contract := func(ctx context.Context) any {
+			var hunters []bson.M
+			HunterModel.Find().Populate("contract").ExecInto(&hunters, ctx)
+			So(hunters, ShouldHaveLength, 1)
+			return hunters[0]["contract"]
+		}
+		So(utils.CastBSON[bson.M](contract(kaerMorhen))["monster"], ShouldEqual, "Katakan")
+		So(contract(novigrad), ShouldBeNil)
+	})
+
+	Convey("Keep the chunk checkpoints of each tenant apart", t, func() {
+		opts := elemental.ChunkOptions{Checkpoint: "hunt-contracts"}
+		batches := 0
+		So(func() {
+			ContractModel.Chunk(1, func(contracts []Contract) error {
+				if batches++; batches > 1 {
+					return errors.New("interrupted")
+				}
+				return nil
+			}, opts).Exec(kaerMorhen)
+		}, ShouldPanic)
+		checkpoints := ContractModel.Collection().Database().Collection(elemental.DefaultCheckpointCollection)
+		So(lo.Must(checkpoints.CountDocuments(context.Background(), primitive.M{"_id": "contracts:kaer-morhen:hunt-contracts"})), ShouldEqual, 1)
+		count := func(contracts []Contract) error { return nil }
+		result := ContractModel.Chunk(1, count, opts).Exec(novigrad).(elemental.ChunkResult)
+		So(result.Documents, ShouldEqual, ContractModel.CountDocuments().Exec(novigrad))
+		result = ContractModel.Chunk(1, count, opts).Exec(kaerMorhen).(elemental.ChunkResult)
+		So(result.Documents, ShouldEqual, ContractModel.CountDocuments().Exec(kaerMorhen).(int64)-1)
+	})
+
+	Convey("Isolate documents by a database per tenant", t, func() {
+		TenantContractModel := elemental.NewModel[Contract]("Contract-With-Tenant-Databases", elemental.NewSchema(definitions, elemental.SchemaOptions{
+			Collection: "contracts",
+			Tenancy: &elemental.Tenancy{
+				Mode: elemental.TenancyModeDatabase,
+				Database: func(tenant any) string {
+					return t.Name() + "_" + tenant.(string)
+				},
+			},
+		}))
+		TenantContractModel.Create(Contract{Monster: "Leshen", Reward: 1000}).Exec(kaerMorhen)
+		So(TenantContractModel.Find().ExecTT(novigrad), ShouldBeEmpty)
+		So(monsters(TenantContractModel.Find().ExecTT(kaerMorhen)), ShouldResemble, []string{"Leshen"})
+		So(lo.Must(elemental.UseDatabase(t.Name()+"_kaer-morhen").Collection("contracts").CountDocuments(context.Background(), primitive.M{})), ShouldEqual, 1)
+		So(func() {
+			TenantContractModel.Find().Exec()
+		}, ShouldPanicWith, elemental.ErrTenantRequired)
+	})
+}
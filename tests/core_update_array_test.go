@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreUpdateArray(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	type Contract struct {
+		Monster   string `json:"monster" bson:"monster"`
+		Reward    int    `json:"reward" bson:"reward"`
+		Completed bool   `json:"completed" bson:"completed"`
+	}
+
+	type Witcher struct {
+		ID        primitive.ObjectID `json:"_id" bson:"_id"`
+		Name      string             `json:"name" bson:"name"`
+		Contracts []Contract         `json:"contracts" bson:"contracts"`
+	}
+
+	WitcherModel := elemental.NewModel[Witcher]("Witcher-For-Array-Updates", elemental.NewSchema(map[string]elemental.Field{
+		"Name": {
+			Type:     elemental.String,
+			Required: true,
+		},
+		"Contracts": {
+			Type: elemental.Slice,
+		},
+	}, elemental.SchemaOptions{
+		Collection: "witchers",
+	})).SetDatabase(t.Name())
+
+	WitcherModel.InsertMany([]Witcher{
+		{Name: "Geralt", Contracts: []Contract{{Monster: "Griffin", Reward: 200}, {Monster: "Noonwraith", Reward: 80}, {Monster: "Leshen", Reward: 400}}},
+		{Name: "Lambert", Contracts: []Contract{{Monster: "Drowner", Reward: 20}, {Monster: "Griffin", Reward: 250}}},
+		{Name: "Eskel", Contracts: []Contract{{Monster: "Foglet", Reward: 60}}},
+		{Name: "Letho", Contracts: []Contract{{Monster: "Wyvern", Reward: 150}, {Monster: "Bruxa", Reward: 600}}},
+	}).Exec()
+
+	contract := func(name, monster string) Contract {
+		for _, c := range WitcherModel.FindOne(primitive.M{"name": name}).ExecT().Contracts {
+			if c.Monster == monster {
+				return c
+			}
+		}
+		return Contract{}
+	}
+
+	Convey("Update elements of arrays", t, func() {
+		Convey("Update the first matching element with the positional operator", func() {
+			WitcherModel.Where("name", "Geralt").Where("contracts.monster", "Noonwraith").
+				Set(primitive.M{"contracts.$.completed": true}).Exec()
+			So(contract("Geralt", "Noonwraith").Completed, ShouldBeTrue)
+			So(contract("Geralt", "Griffin").Completed, ShouldBeFalse)
+		})
+		Convey("Update all elements", func() {
+			WitcherModel.Where("name", "Eskel").UpdateArrayElement("contracts", nil, primitive.M{"reward": 0}).Exec()
+			So(contract("Eskel", "Foglet").Reward, ShouldEqual, 0)
+		})
+		Convey("Update elements matching a condition across documents", func() {
+			WitcherModel.UpdateArrayElement("contracts", primitive.M{"monster": "Griffin"}, primitive.M{"completed": true}).Exec()
+			So(contract("Geralt", "Griffin").Completed, ShouldBeTrue)
+			So(contract("Lambert", "Griffin").Completed, ShouldBeTrue)
+			So(contract("Lambert", "Drowner").Completed, ShouldBeFalse)
+		})
+		Convey("Chain multiple element updates with other operators", func() {
+			witcher := WitcherModel.Where("name", "Letho").
+				UpdateArrayElement("contracts", primitive.M{"reward": primitive.M{"$gte": 500}}, primitive.M{"completed": true}).
+				UpdateArrayElement("contracts", primitive.M{"monster": "Wyvern"}, primitive.M{"reward": 175}).
+				Set(primitive.M{"name": "Letho of Gulet"}).
+				FindOneAndModify().New().ExecT()
+			So(witcher.Name, ShouldEqual, "Letho of Gulet")
+			So(witcher.Contracts[0], ShouldResemble, Contract{Monster: "Wyvern", Reward: 175})
+			So(witcher.Contracts[1], ShouldResemble, Contract{Monster: "Bruxa", Reward: 600, Completed: true})
+		})
+		Convey("Explicit array filters", func() {
+			WitcherModel.Where("name", "Geralt").
+				Set(primitive.M{"contracts.$[expensive].reward": 500}).
+				ArrayFilters(primitive.M{"expensive.reward": primitive.M{"$gt": 300}}).
+				One().Exec()
+			So(contract("Geralt", "Leshen").Reward, ShouldEqual, 500)
+			So(contract("Geralt", "Griffin").Reward, ShouldEqual, 200)
+		})
+		Convey("Update elements of an array of values", func() {
+			UserModel.Where("name", mocks.Geralt.Name).UpdateArrayElement("weapons", "Crossbow", "Heavy crossbow").Exec()
+			user := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT()
+			So(user.Weapons, ShouldContain, "Heavy crossbow")
+			So(user.Weapons, ShouldNotContain, "Crossbow")
+		})
+	})
+}
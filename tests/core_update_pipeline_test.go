@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCoreUpdatePipeline(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	invokedHooks := make(map[string]bool)
+
+	PipelineUserModel := elemental.NewModel[User]("User-For-Pipeline-Updates", elemental.NewSchema(UserModel.Schema.Definitions, elemental.SchemaOptions{
+		Collection: "users",
+	})).SetDatabase(t.Name())
+
+	PipelineUserModel.PreUpdateOne(func(doc any) bool {
+		invokedHooks["preUpdateOne"] = true
+		return true
+	})
+
+	PipelineUserModel.PostUpdateOne(func(result *mongo.UpdateResult, err error) bool {
+		invokedHooks["postUpdateOne"] = true
+		return true
+	})
+
+	PipelineUserModel.PostFindOneAndUpdate(func(doc *User) bool {
+		invokedHooks["postFindOneAndUpdate"] = true
+		return true
+	})
+
+	Convey("Update users with a pipeline", t, func() {
+		Convey("Compute a field from other fields of the same document", func() {
+			result := PipelineUserModel.UpdateWithPipeline(&primitive.M{"occupation": "Witcher"},
+				bson.D{{Key: "$set", Value: primitive.M{"occupation": primitive.M{"$concat": bson.A{"$occupation", " of ", "$school"}}}}},
+			).Exec().(*mongo.UpdateResult)
+			So(result.ModifiedCount, ShouldEqual, 2)
+			So(PipelineUserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT().Occupation, ShouldEqual, "Witcher of Wolf")
+		})
+		Convey("Update conditionally", func() {
+			PipelineUserModel.UpdateWithPipeline(nil,
+				bson.D{{Key: "$set", Value: primitive.M{"retired": primitive.M{"$gte": bson.A{"$age", 150}}}}},
+			).Exec()
+			So(PipelineUserModel.FindOne(primitive.M{"name": mocks.Imlerith.Name}).ExecT().Retired, ShouldBeTrue)
+			So(PipelineUserModel.FindOne(primitive.M{"name": mocks.Caranthir.Name}).ExecT().Retired, ShouldBeFalse)
+		})
+		Convey("Update the first matching document", func() {
+			PipelineUserModel.Where("name", mocks.Caranthir.Name).UpdateWithPipeline(nil,
+				bson.D{{Key: "$set", Value: primitive.M{"age": primitive.M{"$multiply": bson.A{"$age", 2}}}}},
+				bson.D{{Key: "$unset", Value: "weapons"}},
+			).One().Exec()
+			user := PipelineUserModel.FindOne(primitive.M{"name": mocks.Caranthir.Name}).ExecT()
+			So(user.Age, ShouldEqual, mocks.Caranthir.Age*2)
+			So(user.Weapons, ShouldBeEmpty)
+			So(invokedHooks["preUpdateOne"], ShouldBeTrue)
+			So(invokedHooks["postUpdateOne"], ShouldBeTrue)
+		})
+		Convey("Update the first matching document and return it", func() {
+			user := PipelineUserModel.UpdateWithPipeline(&primitive.M{"name": mocks.Yennefer.Name},
+				bson.D{{Key: "$replaceWith", Value: primitive.M{"$mergeObjects": bson.A{"$$ROOT", primitive.M{"occupation": "Sorceress"}}}}},
+			).FindOneAndModify().New().ExecT()
+			So(user.Occupation, ShouldEqual, "Sorceress")
+			So(user.Age, ShouldEqual, mocks.Yennefer.Age)
+			So(invokedHooks["postFindOneAndUpdate"], ShouldBeTrue)
+		})
+		Convey("Skip soft deleted documents", func() {
+			SoftDeleteUserModel := PipelineUserModel
+			SoftDeleteUserModel.EnableSoftDelete()
+			SoftDeleteUserModel.DeleteOne(primitive.M{"name": mocks.Eredin.Name}).Exec()
+			result := SoftDeleteUserModel.UpdateWithPipeline(&primitive.M{"name": mocks.Eredin.Name},
+				bson.D{{Key: "$set", Value: primitive.M{"occupation": "King of the Wild Hunt"}}},
+			).Exec().(*mongo.UpdateResult)
+			So(result.MatchedCount, ShouldEqual, 0)
+		})
+	})
+}
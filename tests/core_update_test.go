@@ -1,14 +1,17 @@
 package tests
 
 import (
+	"context"
 	"testing"
 
+	elemental "github.com/elcengine/elemental/core"
 	"github.com/elcengine/elemental/tests/fixtures"
 	"github.com/elcengine/elemental/tests/fixtures/mocks"
 	ts "github.com/elcengine/elemental/tests/fixtures/setup"
 	"github.com/samber/lo"
 	. "github.com/smartystreets/goconvey/convey"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -144,6 +147,13 @@ func TestCoreUpdate(t *testing.T) {
 			updatedUser := UserModel.FindOne().Where("name", mocks.Vesemir.Name).ExecT()
 			So(updatedUser.Age, ShouldEqual, mocks.Vesemir.Age)
 		})
+		Convey("Refuse to divide age of a user by zero", func() {
+			So(func() {
+				UserModel.Where("name", mocks.Vesemir.Name).Div("age", 0).Exec()
+			}, ShouldPanicWith, elemental.ErrDivisionByZero)
+			updatedUser := UserModel.FindOne().Where("name", mocks.Vesemir.Name).ExecT()
+			So(updatedUser.Age, ShouldEqual, mocks.Vesemir.Age)
+		})
 		Convey("Rename occupation field to profession", func() {
 			UserModel.Rename("occupation", "profession").Exec()
 			user := UserModel.FindOne().Where("name", mocks.Vesemir.Name).ExecT()
@@ -221,5 +231,47 @@ func TestCoreUpdate(t *testing.T) {
 			updatedUser = UserModel.FindOne().Where("name", mocks.Yennefer.Name).ExecT()
 			So(updatedUser.Age, ShouldEqual, 80)
 		})
+		Convey("Chain multiple update operators into a single update", func() {
+			UserModel.Create(User{Name: "Regis", Age: 400, Weapons: []string{"Claws"}}).Exec()
+			result := UserModel.Where("name", "Regis").
+				Set(primitive.M{"occupation": "Barber surgeon"}).
+				Mul("age", 1.5).
+				Inc("stamina", int64(5)).
+				Push("weapons", "Scalpel").
+				Push("weapons", "Bone saw", "Mandrake").
+				Exec().(*mongo.UpdateResult)
+			So(result.ModifiedCount, ShouldEqual, 1)
+			user := UserModel.FindOne().Where("name", "Regis").ExecT()
+			So(user.Occupation, ShouldEqual, "Barber surgeon")
+			So(user.Age, ShouldEqual, 600)
+			So(user.Weapons, ShouldResemble, []string{"Claws", "Scalpel", "Bone saw", "Mandrake"})
+			raw := primitive.M{}
+			lo.Must0(UserModel.Collection().FindOne(context.Background(), primitive.M{"_id": user.ID}).Decode(&raw))
+			So(raw["stamina"], ShouldEqual, int64(5))
+		})
+		Convey("Refuse conflicting update operators on the same field", func() {
+			So(func() {
+				UserModel.Where("name", mocks.Geralt.Name).Inc("age", 1).Mul("age", 2).Exec()
+			}, ShouldPanic)
+		})
+		Convey("Update only the first matching document and return it", func() {
+			user := UserModel.Where("name", mocks.Caranthir.Name).Dec("age", uint8(10)).Set(primitive.M{"retired": true}).FindOneAndModify().New().ExecT()
+			So(user.Age, ShouldEqual, mocks.Caranthir.Age-10)
+			So(user.Retired, ShouldBeTrue)
+			result := UserModel.Where("occupation", "Mage").Set(primitive.M{"retired": true}).One().Exec().(*mongo.UpdateResult)
+			So(result.MatchedCount, ShouldEqual, 1)
+		})
+		Convey("Set fields only when a document is inserted", func() {
+			UserModel.Where("name", "Dettlaff").Set(primitive.M{"age": 500}).SetOnInsert(primitive.M{"occupation": "Vampire"}).Upsert().Exec()
+			UserModel.Where("name", "Dettlaff").Set(primitive.M{"age": 501}).SetOnInsert(primitive.M{"occupation": "Higher vampire"}).Upsert().Exec()
+			user := UserModel.FindOne().Where("name", "Dettlaff").ExecT()
+			So(user.Age, ShouldEqual, 501)
+			So(user.Occupation, ShouldEqual, "Vampire")
+		})
+		Convey("Reject values which are not numbers", func() {
+			So(func() {
+				UserModel.Where("name", "Regis").Inc("age", "1")
+			}, ShouldPanic)
+		})
 	})
 }
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	elemental "github.com/elcengine/elemental/core"
+	"github.com/elcengine/elemental/tests/fixtures/mocks"
+	ts "github.com/elcengine/elemental/tests/fixtures/setup"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoreUpsert(t *testing.T) {
+	t.Parallel()
+
+	ts.SeededConnection(t.Name())
+
+	UserModel := UserModel.SetDatabase(t.Name())
+
+	UserModel.SyncIndexes()
+
+	Convey("Find or create users", t, func() {
+		Convey("Find an existing user", func() {
+			result := UserModel.FindOrCreate(primitive.M{"name": mocks.Geralt.Name}, User{Age: 1}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeFalse)
+			So(result.Document.Age, ShouldEqual, mocks.Geralt.Age)
+			So(result.Document.Occupation, ShouldEqual, mocks.Geralt.Occupation)
+		})
+		Convey("Create a user which does not exist", func() {
+			result := UserModel.FindOrCreate(primitive.M{"name": "Regis"}, User{Age: 400}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeTrue)
+			So(result.Document.Name, ShouldEqual, "Regis")
+			So(result.Document.ID.IsZero(), ShouldBeFalse)
+			So(result.Document.CreatedAt.IsZero(), ShouldBeFalse)
+			user := UserModel.FindOne(primitive.M{"name": "Regis"}).ExecT()
+			So(user.ID, ShouldEqual, result.Document.ID)
+			So(user.Age, ShouldEqual, 400)
+		})
+		Convey("Validate the document to create", func() {
+			So(func() {
+				UserModel.FindOrCreate(primitive.M{"age": 999}, User{}).Exec()
+			}, ShouldPanic)
+			So(UserModel.FindOne(primitive.M{"age": 999}).Exec(), ShouldBeNil)
+		})
+	})
+
+	Convey("Update or create users", t, func() {
+		Convey("Update an existing user", func() {
+			result := UserModel.UpdateOrCreate(primitive.M{"name": mocks.Yennefer.Name}, primitive.M{"age": 101}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeFalse)
+			So(result.Document.Age, ShouldEqual, 101)
+			So(result.Document.Occupation, ShouldEqual, mocks.Yennefer.Occupation)
+		})
+		Convey("Create a user which does not exist", func() {
+			result := UserModel.UpdateOrCreate(primitive.M{"name": "Dettlaff"}, primitive.M{"age": 500}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeTrue)
+			So(result.Document.Name, ShouldEqual, "Dettlaff")
+			So(result.Document.Age, ShouldEqual, 500)
+			So(result.Document.CreatedAt.IsZero(), ShouldBeFalse)
+		})
+		Convey("Update an existing user by id without repeating its required fields", func() {
+			geralt := UserModel.FindOne(primitive.M{"name": mocks.Geralt.Name}).ExecT()
+			result := UserModel.UpdateOrCreate(primitive.M{"_id": geralt.ID}, primitive.M{"age": 5}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeFalse)
+			So(result.Document.Name, ShouldEqual, mocks.Geralt.Name)
+			So(result.Document.Age, ShouldEqual, 5)
+		})
+		Convey("Update or create by id", func() {
+			id := primitive.NewObjectID()
+			result := UserModel.UpdateOrCreate(primitive.M{"_id": id}, User{Name: "Emiel", Age: 300}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeTrue)
+			So(result.Document.ID, ShouldEqual, id)
+			result = UserModel.UpdateOrCreate(primitive.M{"_id": id}, primitive.M{"age": 301}).Exec().(elemental.UpsertResult[User])
+			So(result.Created, ShouldBeFalse)
+			So(result.Document.Name, ShouldEqual, "Emiel")
+			So(result.Document.Age, ShouldEqual, 301)
+		})
+	})
+
+	Convey("Upsert many users", t, func() {
+		result := UserModel.UpsertMany([]User{
+			{Name: mocks.Ciri.Name, Age: 21, Occupation: "Witcher"},
+			{Name: "Orianna", Age: 400},
+		}, "name").Exec()
+		So(result.Errors, ShouldBeEmpty)
+		So(result.MatchedCount, ShouldEqual, 1)
+		So(result.UpsertedCount, ShouldEqual, 1)
+		So(result.UpsertedIDs, ShouldNotContainKey, 0)
+		So(result.UpsertedIDs, ShouldContainKey, 1)
+		ciri := UserModel.FindOne(primitive.M{"name": mocks.Ciri.Name}).ExecT()
+		So(ciri.Age, ShouldEqual, 21)
+		So(ciri.Occupation, ShouldEqual, "Witcher")
+		So(UserModel.FindOne(primitive.M{"name": "Orianna"}).ExecT().ID, ShouldEqual, result.UpsertedIDs[1])
+	})
+}